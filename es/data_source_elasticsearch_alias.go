@@ -0,0 +1,188 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// aliasResponse is the alias of an index in the response of `GET _alias`
+type aliasResponse struct {
+	Filter        map[string]interface{} `json:"filter"`
+	IndexRouting  string                 `json:"index_routing"`
+	SearchRouting string                 `json:"search_routing"`
+}
+
+func dataSourceElasticsearchAlias() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_alias` can be used to retrieve all the indices of an alias, along with its write index and its definition on each of them, e.g. to iterate over the indices of a rollover series.",
+		Read:        dataSourceElasticsearchAliasRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the alias",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of all the indices of the alias, sorted",
+			},
+			"write_index": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The write index of the alias, either the index marked with `is_write_index` or the only index of the alias, empty when the alias has no write index",
+			},
+			"index_aliases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The definition of the alias on each of its indices, sorted by index",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_write_index": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"filter": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index_routing": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"search_routing": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchAliasRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	indices, writeIndex, err := getAliasIndices(esClient, name)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return fmt.Errorf("alias %s not found", name)
+	}
+	// an alias of a single index writes to it without is_write_index
+	if writeIndex == "" && len(indices) == 1 {
+		writeIndex = indices[0]
+	}
+
+	aliases, err := getIndexAliases(esClient, name)
+	if err != nil {
+		return err
+	}
+	indexAliases := make([]map[string]interface{}, 0, len(indices))
+	for _, index := range indices {
+		alias := aliases[index]
+		filter := ""
+		if len(alias.Filter) > 0 {
+			bytes, err := json.Marshal(alias.Filter)
+			if err != nil {
+				return err
+			}
+			filter = string(bytes)
+		}
+		indexAliases = append(indexAliases, map[string]interface{}{
+			"index":          index,
+			"is_write_index": index == writeIndex,
+			"filter":         filter,
+			"index_routing":  alias.IndexRouting,
+			"search_routing": alias.SearchRouting,
+		})
+	}
+
+	d.SetId(name)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("indices", indices)
+	ds.set("write_index", writeIndex)
+	ds.set("index_aliases", indexAliases)
+	return ds.err
+}
+
+// getIndexAliases returns the definition of an alias on each of its indices
+func getIndexAliases(esClient interface{}, name string) (map[string]aliasResponse, error) {
+	var (
+		ctx  = context.Background()
+		body json.RawMessage
+	)
+
+	path, err := uritemplates.Expand("/_alias/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for alias %s: %+v", name, err)
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(ctx, "GET", path, nil, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response map[string]struct {
+		Aliases map[string]aliasResponse `json:"aliases"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, body)
+	}
+
+	aliases := make(map[string]aliasResponse, len(response))
+	for index, r := range response {
+		aliases[index] = r.Aliases[name]
+	}
+	return aliases, nil
+}
@@ -0,0 +1,76 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceAlias_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchDataSourceAliasMissing,
+				ExpectError: regexp.MustCompile("alias terraform-test-missing-alias not found"),
+			},
+			{
+				Config: testAccElasticsearchDataSourceAlias,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "indices.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "indices.0", "terraform-test-alias-1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "indices.1", "terraform-test-alias-2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "write_index", ""),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "index_aliases.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "index_aliases.0.index", "terraform-test-alias-1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "index_aliases.0.index_routing", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "index_aliases.1.index", "terraform-test-alias-2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_alias.test", "index_aliases.1.filter", `{"term":{"user":"kimchy"}}`),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceAliasMissing = `
+data "elasticsearch_alias" "test" {
+  name = "terraform-test-missing-alias"
+}
+`
+
+var testAccElasticsearchDataSourceAlias = `
+resource "elasticsearch_index" "first" {
+  name = "terraform-test-alias-1"
+  number_of_shards = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-data-alias" = {
+      "routing" = "1"
+    }
+  })
+}
+
+resource "elasticsearch_index" "second" {
+  name = "terraform-test-alias-2"
+  number_of_shards = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-data-alias" = {
+      "filter" = {
+        "term" = {
+          "user" = "kimchy"
+        }
+      }
+    }
+  })
+}
+
+data "elasticsearch_alias" "test" {
+  name = "${element(list("terraform-test-data-alias", elasticsearch_index.first.id, elasticsearch_index.second.id), 0)}"
+}
+`
@@ -0,0 +1,354 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// clusterHealth holds the version independent subset of a cluster health
+// response
+type clusterHealth struct {
+	ClusterName             string `json:"cluster_name"`
+	Status                  string `json:"status"`
+	TimedOut                bool   `json:"timed_out"`
+	NumberOfNodes           int    `json:"number_of_nodes"`
+	NumberOfDataNodes       int    `json:"number_of_data_nodes"`
+	ActivePrimaryShards     int    `json:"active_primary_shards"`
+	ActiveShards            int    `json:"active_shards"`
+	RelocatingShards        int    `json:"relocating_shards"`
+	InitializingShards      int    `json:"initializing_shards"`
+	UnassignedShards        int    `json:"unassigned_shards"`
+	DelayedUnassignedShards int    `json:"delayed_unassigned_shards"`
+	NumberOfPendingTasks    int    `json:"number_of_pending_tasks"`
+}
+
+func dataSourceElasticsearchClusterHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_cluster_health` can be used to retrieve the health of the provider's current elasticsearch cluster, optionally scoped to a set of indices.",
+		Read:        dataSourceElasticsearchClusterHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An index name or pattern (e.g. `logs-*`) to scope the health to, defaults to the whole cluster",
+			},
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red"}, false),
+				Description:  "Wait until the cluster or the indices reach at least this status, `green`, `yellow` or `red`, failing if they don't within `timeout`",
+			},
+			"wait_for_no_relocating_shards": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Wait until no shards are relocating, e.g. after changing allocation settings, failing if they still are after `timeout`",
+			},
+			"wait_for_no_initializing_shards": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Wait until no shards are initializing, failing if they still are after `timeout` (ES >= 6.2)",
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `30s`"),
+				Description:  "How long to wait for `wait_for_status` and the other `wait_for_*` options, e.g. `5m`, defaults to `30s`",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the cluster",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health status of the cluster or the indices, one of `green`, `yellow` or `red`",
+			},
+			"timed_out": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the request timed out",
+			},
+			"number_of_nodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of nodes in the cluster",
+			},
+			"number_of_data_nodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of data nodes in the cluster",
+			},
+			"active_primary_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of active primary shards",
+			},
+			"active_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of active primary and replica shards",
+			},
+			"relocating_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of shards that are relocating",
+			},
+			"initializing_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of shards that are initializing",
+			},
+			"unassigned_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of shards that are not allocated",
+			},
+			"delayed_unassigned_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of shards whose allocation has been delayed",
+			},
+			"number_of_pending_tasks": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of cluster-level changes that have not yet been executed",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchClusterHealthRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		ctx                         = context.Background()
+		health                      clusterHealth
+		indices                     []string
+		waitForStatus               = d.Get("wait_for_status").(string)
+		waitForNoRelocatingShards   = d.Get("wait_for_no_relocating_shards").(bool)
+		waitForNoInitializingShards = d.Get("wait_for_no_initializing_shards").(bool)
+		timeout                     = d.Get("timeout").(string)
+	)
+	if index, ok := d.GetOk("index"); ok {
+		indices = []string{index.(string)}
+	}
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		if waitForNoInitializingShards {
+			health, err = clusterHealthRequest(esClient, d)
+			break
+		}
+		service := client.ClusterHealth().Index(indices...)
+		if waitForStatus != "" {
+			service = service.WaitForStatus(waitForStatus)
+		}
+		if waitForNoRelocatingShards {
+			service = service.WaitForNoRelocatingShards(true)
+		}
+		if timeout != "" {
+			service = service.Timeout(timeout)
+		}
+		var res *elastic7.ClusterHealthResponse
+		res, err = service.Do(ctx)
+		if elastic7.IsTimeout(err) {
+			return fmt.Errorf("cluster health did not reach the awaited state within the timeout: %+v", err)
+		}
+		if err == nil {
+			health = clusterHealth{
+				ClusterName:             res.ClusterName,
+				Status:                  res.Status,
+				TimedOut:                res.TimedOut,
+				NumberOfNodes:           res.NumberOfNodes,
+				NumberOfDataNodes:       res.NumberOfDataNodes,
+				ActivePrimaryShards:     res.ActivePrimaryShards,
+				ActiveShards:            res.ActiveShards,
+				RelocatingShards:        res.RelocatingShards,
+				InitializingShards:      res.InitializingShards,
+				UnassignedShards:        res.UnassignedShards,
+				DelayedUnassignedShards: res.DelayedUnassignedShards,
+				NumberOfPendingTasks:    res.NumberOfPendingTasks,
+			}
+		}
+	case *elastic6.Client:
+		if waitForNoInitializingShards {
+			health, err = clusterHealthRequest(esClient, d)
+			break
+		}
+		service := client.ClusterHealth().Index(indices...)
+		if waitForStatus != "" {
+			service = service.WaitForStatus(waitForStatus)
+		}
+		if waitForNoRelocatingShards {
+			service = service.WaitForNoRelocatingShards(true)
+		}
+		if timeout != "" {
+			service = service.Timeout(timeout)
+		}
+		var res *elastic6.ClusterHealthResponse
+		res, err = service.Do(ctx)
+		if elastic6.IsTimeout(err) {
+			return fmt.Errorf("cluster health did not reach the awaited state within the timeout: %+v", err)
+		}
+		if err == nil {
+			health = clusterHealth{
+				ClusterName:             res.ClusterName,
+				Status:                  res.Status,
+				TimedOut:                res.TimedOut,
+				NumberOfNodes:           res.NumberOfNodes,
+				NumberOfDataNodes:       res.NumberOfDataNodes,
+				ActivePrimaryShards:     res.ActivePrimaryShards,
+				ActiveShards:            res.ActiveShards,
+				RelocatingShards:        res.RelocatingShards,
+				InitializingShards:      res.InitializingShards,
+				UnassignedShards:        res.UnassignedShards,
+				DelayedUnassignedShards: res.DelayedUnassignedShards,
+				NumberOfPendingTasks:    res.NumberOfPendingTasks,
+			}
+		}
+	default:
+		if waitForNoInitializingShards {
+			return fmt.Errorf("wait_for_no_initializing_shards is only supported from ElasticSearch >= 6.2, got version < 6.0.0")
+		}
+		elastic5Client := client.(*elastic5.Client)
+		service := elastic5Client.ClusterHealth().Index(indices...)
+		if waitForStatus != "" {
+			service = service.WaitForStatus(waitForStatus)
+		}
+		if waitForNoRelocatingShards {
+			service = service.WaitForNoRelocatingShards(true)
+		}
+		if timeout != "" {
+			service = service.Timeout(timeout)
+		}
+		var res *elastic5.ClusterHealthResponse
+		res, err = service.Do(ctx)
+		if elastic5.IsTimeout(err) {
+			return fmt.Errorf("cluster health did not reach the awaited state within the timeout: %+v", err)
+		}
+		if err == nil {
+			health = clusterHealth{
+				ClusterName:             res.ClusterName,
+				Status:                  res.Status,
+				TimedOut:                res.TimedOut,
+				NumberOfNodes:           res.NumberOfNodes,
+				NumberOfDataNodes:       res.NumberOfDataNodes,
+				ActivePrimaryShards:     res.ActivePrimaryShards,
+				ActiveShards:            res.ActiveShards,
+				RelocatingShards:        res.RelocatingShards,
+				InitializingShards:      res.InitializingShards,
+				UnassignedShards:        res.UnassignedShards,
+				DelayedUnassignedShards: res.DelayedUnassignedShards,
+				NumberOfPendingTasks:    res.NumberOfPendingTasks,
+			}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(health.ClusterName)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("cluster_name", health.ClusterName)
+	ds.set("status", health.Status)
+	ds.set("timed_out", health.TimedOut)
+	ds.set("number_of_nodes", health.NumberOfNodes)
+	ds.set("number_of_data_nodes", health.NumberOfDataNodes)
+	ds.set("active_primary_shards", health.ActivePrimaryShards)
+	ds.set("active_shards", health.ActiveShards)
+	ds.set("relocating_shards", health.RelocatingShards)
+	ds.set("initializing_shards", health.InitializingShards)
+	ds.set("unassigned_shards", health.UnassignedShards)
+	ds.set("delayed_unassigned_shards", health.DelayedUnassignedShards)
+	ds.set("number_of_pending_tasks", health.NumberOfPendingTasks)
+	return ds.err
+}
+
+// clusterHealthRequest requests the cluster health with
+// wait_for_no_initializing_shards, which the ClusterHealth services lack,
+// along with the other options
+func clusterHealthRequest(esClient interface{}, d *schema.ResourceData) (clusterHealth, error) {
+	var (
+		ctx    = context.Background()
+		health clusterHealth
+		body   json.RawMessage
+		err    error
+	)
+
+	path := "/_cluster/health"
+	if index, ok := d.GetOk("index"); ok {
+		path, err = uritemplates.Expand("/_cluster/health/{index}", map[string]string{
+			"index": index.(string),
+		})
+		if err != nil {
+			return health, fmt.Errorf("error building URL path for cluster health: %+v", err)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("wait_for_no_initializing_shards", "true")
+	if waitForStatus, ok := d.GetOk("wait_for_status"); ok {
+		params.Set("wait_for_status", waitForStatus.(string))
+	}
+	if d.Get("wait_for_no_relocating_shards").(bool) {
+		params.Set("wait_for_no_relocating_shards", "true")
+	}
+	if timeout, ok := d.GetOk("timeout"); ok {
+		params.Set("timeout", timeout.(string))
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+			Params: params,
+		})
+		if elastic7.IsTimeout(err) {
+			return health, fmt.Errorf("cluster health did not reach the awaited state within the timeout: %+v", err)
+		}
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+			Params: params,
+		})
+		if elastic6.IsTimeout(err) {
+			return health, fmt.Errorf("cluster health did not reach the awaited state within the timeout: %+v", err)
+		}
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		return health, fmt.Errorf("wait_for_no_initializing_shards is only supported from ElasticSearch >= 6.2, got version < 6.0.0")
+	}
+
+	if err != nil {
+		return health, err
+	}
+	if err := json.Unmarshal(body, &health); err != nil {
+		return health, fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, body)
+	}
+	return health, nil
+}
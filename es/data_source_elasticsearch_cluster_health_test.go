@@ -0,0 +1,60 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceClusterHealth_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceClusterHealth,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticsearch_cluster_health.test", "id"),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_cluster_health.test", "cluster_name"),
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_health.test", "status", regexp.MustCompile("^(green|yellow|red)$")),
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_health.test", "number_of_nodes", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+			{
+				Config: testAccElasticsearchDataSourceClusterHealthWaitForStatus,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_health.test", "status", regexp.MustCompile("^(green|yellow)$")),
+					resource.TestCheckResourceAttr("data.elasticsearch_cluster_health.test", "timed_out", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchDataSourceClusterHealthWaitForNoRelocatingShards,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_cluster_health.test", "relocating_shards", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_cluster_health.test", "timed_out", "false"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceClusterHealth = `
+data "elasticsearch_cluster_health" "test" {}
+`
+
+var testAccElasticsearchDataSourceClusterHealthWaitForStatus = `
+data "elasticsearch_cluster_health" "test" {
+  wait_for_status = "yellow"
+  timeout         = "1m"
+}
+`
+
+var testAccElasticsearchDataSourceClusterHealthWaitForNoRelocatingShards = `
+data "elasticsearch_cluster_health" "test" {
+  wait_for_no_relocating_shards = true
+  timeout                       = "1m"
+}
+`
@@ -0,0 +1,88 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type clusterSettingsResponse struct {
+	Persistent json.RawMessage `json:"persistent"`
+	Transient  json.RawMessage `json:"transient"`
+	Defaults   json.RawMessage `json:"defaults"`
+}
+
+func dataSourceElasticsearchClusterSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_cluster_settings` can be used to retrieve the persistent, transient and optionally default settings of the provider's current elasticsearch cluster.",
+		Read:        dataSourceElasticsearchClusterSettingsRead,
+
+		Schema: map[string]*schema.Schema{
+			"include_defaults": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to also retrieve the default settings into `defaults`",
+			},
+			"flat_settings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return the settings in the flat form, e.g. `{\"cluster.routing.allocation.enable\": \"all\"}`, rather than the nested one",
+			},
+			"persistent": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A JSON object of the persistent settings",
+			},
+			"transient": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A JSON object of the transient settings",
+			},
+			"defaults": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A JSON object of the default settings, only set with `include_defaults`",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchClusterSettingsRead(d *schema.ResourceData, m interface{}) error {
+	var settings clusterSettingsResponse
+
+	params := url.Values{}
+	params.Set("flat_settings", strconv.FormatBool(d.Get("flat_settings").(bool)))
+	if d.Get("include_defaults").(bool) {
+		params.Set("include_defaults", "true")
+	}
+
+	body, err := clusterSettingsRequest(m, "GET", params, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return fmt.Errorf("error unmarshalling cluster settings body: %+v: %+v", err, body)
+	}
+
+	d.SetId("cluster_settings")
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("persistent", clusterSettingsJSON(settings.Persistent))
+	ds.set("transient", clusterSettingsJSON(settings.Transient))
+	ds.set("defaults", clusterSettingsJSON(settings.Defaults))
+	return ds.err
+}
+
+// clusterSettingsJSON returns the JSON of a group of settings, empty when the
+// cluster omits it, e.g. the defaults unless requested
+func clusterSettingsJSON(settings json.RawMessage) string {
+	if len(settings) == 0 || string(settings) == "null" {
+		return ""
+	}
+	return string(settings)
+}
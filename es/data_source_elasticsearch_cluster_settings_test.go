@@ -0,0 +1,45 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceClusterSettings_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceClusterSettings,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticsearch_cluster_settings.test", "id"),
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_settings.test", "persistent", regexp.MustCompile(`^\{`)),
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_settings.test", "transient", regexp.MustCompile(`^\{`)),
+					resource.TestCheckResourceAttr("data.elasticsearch_cluster_settings.test", "defaults", ""),
+				),
+			},
+			{
+				Config: testAccElasticsearchDataSourceClusterSettingsDefaults,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.elasticsearch_cluster_settings.test", "defaults", regexp.MustCompile(`"cluster\.name":`)),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceClusterSettings = `
+data "elasticsearch_cluster_settings" "test" {}
+`
+
+var testAccElasticsearchDataSourceClusterSettingsDefaults = `
+data "elasticsearch_cluster_settings" "test" {
+  include_defaults = true
+  flat_settings    = true
+}
+`
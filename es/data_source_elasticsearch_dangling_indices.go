@@ -0,0 +1,69 @@
+package es
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceElasticsearchDanglingIndices() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_dangling_indices` can be used to list the dangling indices of the cluster, which can be imported or deleted with the `elasticsearch_dangling_index` resource (ES >= 7.9).",
+		Read:        dataSourceElasticsearchDanglingIndicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"dangling_indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The dangling indices",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_date_millis": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"node_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchDanglingIndicesRead(d *schema.ResourceData, m interface{}) error {
+	client, err := elastic7DanglingIndicesClient(m)
+	if err != nil {
+		return err
+	}
+
+	indices, err := elastic7GetDanglingIndices(client)
+	if err != nil {
+		return err
+	}
+
+	danglingIndices := make([]map[string]interface{}, 0, len(indices))
+	for _, index := range indices {
+		danglingIndices = append(danglingIndices, map[string]interface{}{
+			"index_name":           index.IndexName,
+			"index_uuid":           index.IndexUUID,
+			"creation_date_millis": int(index.CreationDateMillis),
+			"node_ids":             index.NodeIds,
+		})
+	}
+
+	d.SetId("dangling_indices")
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("dangling_indices", danglingIndices)
+	return ds.err
+}
@@ -0,0 +1,106 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func dataSourceElasticsearchDocument() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_document` can be used to retrieve the source of a single document.",
+		Read:        dataSourceElasticsearchDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index, or alias, of the document",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the document",
+			},
+			"document_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "_all",
+				Description: "Mapping type of the document on ES < 7, defaults to any type",
+			},
+			"ignore_missing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return an empty `source` instead of an error when the document doesn't exist",
+			},
+			"found": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the document exists",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON `_source` of the document",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchDocumentRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		index  = d.Get("index").(string)
+		id     = d.Get("id").(string)
+		source *json.RawMessage
+	)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		source, err = elastic7GetObject(client, index, id)
+		if elastic7.IsNotFound(err) {
+			err = errObjNotFound
+		}
+	case *elastic6.Client:
+		source, err = elastic6GetObject(client, d.Get("document_type").(string), index, id)
+		if elastic6.IsNotFound(err) {
+			err = errObjNotFound
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		source, err = elastic5GetObject(elastic5Client, d.Get("document_type").(string), index, id)
+		if elastic5.IsNotFound(err) {
+			err = errObjNotFound
+		}
+	}
+
+	if err == errObjNotFound && d.Get("ignore_missing").(bool) {
+		source, err = nil, nil
+	}
+	if err == errObjNotFound {
+		return fmt.Errorf("document %s not found in index %s", id, index)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("found", source != nil)
+	if source != nil {
+		ds.set("source", string(*source))
+	} else {
+		ds.set("source", "")
+	}
+	return ds.err
+}
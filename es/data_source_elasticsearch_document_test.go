@@ -0,0 +1,99 @@
+package es
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func TestAccElasticsearchDataSourceDocument_basic(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceDocumentIndex,
+			},
+			{
+				PreConfig: func() {
+					body := map[string]interface{}{"name": "terraform"}
+					var err error
+					switch client := esClient.(type) {
+					case *elastic7.Client:
+						_, err = client.Index().Index("terraform-test-document").Id("1").BodyJson(body).Refresh("true").Do(context.TODO())
+					case *elastic6.Client:
+						_, err = client.Index().Index("terraform-test-document").Type("_doc").Id("1").BodyJson(body).Refresh("true").Do(context.TODO())
+					default:
+						elastic5Client := client.(*elastic5.Client)
+						_, err = elastic5Client.Index().Index("terraform-test-document").Type("doc").Id("1").BodyJson(body).Refresh("true").Do(context.TODO())
+					}
+					if err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccElasticsearchDataSourceDocument,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_document.test", "found", "true"),
+					resource.TestCheckResourceAttr("data.elasticsearch_document.test", "source", `{"name":"terraform"}`),
+					resource.TestCheckResourceAttr("data.elasticsearch_document.missing", "found", "false"),
+					resource.TestCheckResourceAttr("data.elasticsearch_document.missing", "source", ""),
+				),
+			},
+			{
+				Config:      testAccElasticsearchDataSourceDocumentMissing,
+				ExpectError: regexp.MustCompile("document 2 not found"),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceDocumentIndex = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-document"
+  number_of_shards = 1
+  number_of_replicas = 0
+  force_destroy = true
+}
+`
+
+var testAccElasticsearchDataSourceDocument = testAccElasticsearchDataSourceDocumentIndex + `
+data "elasticsearch_document" "test" {
+  index = elasticsearch_index.test.name
+  id    = "1"
+}
+
+data "elasticsearch_document" "missing" {
+  index          = elasticsearch_index.test.name
+  id             = "2"
+  ignore_missing = true
+}
+`
+
+var testAccElasticsearchDataSourceDocumentMissing = testAccElasticsearchDataSourceDocumentIndex + `
+data "elasticsearch_document" "missing" {
+  index = elasticsearch_index.test.name
+  id    = "2"
+}
+`
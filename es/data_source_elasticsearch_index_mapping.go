@@ -0,0 +1,103 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func dataSourceElasticsearchIndexMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_index_mapping` can be used to retrieve the mappings of an index, e.g. one managed outside of Terraform.",
+		Read:        dataSourceElasticsearchIndexMappingRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index, or of an alias or pattern whose indices all have the same mappings",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the indices the mappings were retrieved from",
+			},
+			"mappings": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON mappings, in the typeless layout of ES >= 7 regardless of the version of the cluster",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchIndexMappingRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		ctx   = context.Background()
+		index = d.Get("index").(string)
+		resp  map[string]interface{}
+	)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		resp, err = client.GetMapping().Index(index).Do(ctx)
+	case *elastic6.Client:
+		resp, err = client.GetMapping().Index(index).Do(ctx)
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		resp, err = elastic5Client.GetMapping().Index(index).Do(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	indices := make([]string, 0, len(resp))
+	for name := range resp {
+		indices = append(indices, name)
+	}
+	sort.Strings(indices)
+	if len(indices) == 0 {
+		return fmt.Errorf("no index found for %s", index)
+	}
+
+	var mappings map[string]interface{}
+	for _, name := range indices {
+		indexMappings := map[string]interface{}{}
+		if r, ok := resp[name].(map[string]interface{}); ok {
+			if raw, ok := r["mappings"].(map[string]interface{}); ok {
+				indexMappings = normalizeIndexMappings(raw)
+			}
+		}
+
+		if mappings == nil {
+			mappings = indexMappings
+		} else if !reflect.DeepEqual(mappings, indexMappings) {
+			return fmt.Errorf("the indices of %s have different mappings, %s and %s differ", index, indices[0], name)
+		}
+	}
+
+	mappingsJSON, err := json.Marshal(mappings)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(index)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("indices", indices)
+	ds.set("mappings", string(mappingsJSON))
+	return ds.err
+}
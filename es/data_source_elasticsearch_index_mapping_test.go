@@ -0,0 +1,51 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceIndexMapping_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceIndexMapping,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_index_mapping.test", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_mapping.test", "indices.0", "terraform-test-mapping"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_mapping.test", "mappings", `{"properties":{"email":{"type":"keyword"}}}`),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceIndexMapping = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-mapping"
+  number_of_shards = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-mapping-alias" = {}
+  })
+  mappings = jsonencode({
+    "doc" = {
+      "properties" = {
+        "email" = {
+          "type" = "keyword"
+        }
+      }
+    }
+  })
+}
+
+data "elasticsearch_index_mapping" "test" {
+  index = "${element(list("terraform-test-mapping-alias", elasticsearch_index.test.id), 0)}"
+}
+`
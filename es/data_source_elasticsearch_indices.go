@@ -0,0 +1,217 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// catIndicesRow is a row of the _cat/indices API, requested with bytes=b so
+// that sizes are returned as plain numbers
+type catIndicesRow struct {
+	Index        string  `json:"index"`
+	Health       string  `json:"health"`
+	Status       string  `json:"status"`
+	DocsCount    *string `json:"docs.count"`
+	StoreSize    *string `json:"store.size"`
+	PriStoreSize *string `json:"pri.store.size"`
+}
+
+func dataSourceElasticsearchIndices() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_indices` can be used to list the indices matching a pattern, along with their document counts and sizes.",
+		Read:        dataSourceElasticsearchIndicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"pattern": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "An index name or wildcard pattern, e.g. `logs-*`",
+			},
+			"expand_wildcards": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma separated list of the index states wildcards are expanded to, e.g. `open`, `closed`, `hidden`, `all` or `none`",
+			},
+			"include_hidden": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to include hidden indices (ES >= 7.7), adds `hidden` to `expand_wildcards`",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the matching indices, sorted by name",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching indices, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"docs_count": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"store_size_in_bytes": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"primary_store_size_in_bytes": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchIndicesRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		pattern = d.Get("pattern").(string)
+		ctx     = context.Background()
+		body    json.RawMessage
+		rows    []catIndicesRow
+	)
+
+	// the olivere cat indices services don't support expand_wildcards, so the
+	// request is made directly
+	path, err := uritemplates.Expand("/_cat/indices/{index}", map[string]string{
+		"index": pattern,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for indices: %+v", err)
+	}
+
+	params := url.Values{}
+	params.Set("format", "json")
+	params.Set("bytes", "b")
+	params.Set("s", "index")
+	params.Set("h", "index,health,status,docs.count,store.size,pri.store.size")
+
+	expandWildcards := d.Get("expand_wildcards").(string)
+	if d.Get("include_hidden").(bool) {
+		if expandWildcards == "" {
+			expandWildcards = "open"
+		}
+		expandWildcards = expandWildcards + ",hidden"
+	}
+	if expandWildcards != "" {
+		params.Set("expand_wildcards", expandWildcards)
+	}
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+			Params: params,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+			Params: params,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(ctx, "GET", path, params, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return fmt.Errorf("error unmarshalling indices body: %+v: %+v", err, body)
+	}
+
+	names := make([]string, 0, len(rows))
+	indices := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		docsCount, err := catIndicesNumber(row.DocsCount)
+		if err != nil {
+			return err
+		}
+		storeSize, err := catIndicesNumber(row.StoreSize)
+		if err != nil {
+			return err
+		}
+		priStoreSize, err := catIndicesNumber(row.PriStoreSize)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, row.Index)
+		indices = append(indices, map[string]interface{}{
+			"name":                        row.Index,
+			"health":                      row.Health,
+			"status":                      row.Status,
+			"docs_count":                  docsCount,
+			"store_size_in_bytes":         storeSize,
+			"primary_store_size_in_bytes": priStoreSize,
+		})
+	}
+
+	d.SetId(pattern)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("indices", indices)
+	return ds.err
+}
+
+// catIndicesNumber parses a numeric _cat column, which is null for closed
+// indices, as a float so that sizes over 2GiB don't overflow the int of 32-bit
+// builds
+func catIndicesNumber(value *string) (float64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(*value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing _cat/indices number %q: %+v", *value, err)
+	}
+	return float64(i), nil
+}
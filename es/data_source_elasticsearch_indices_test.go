@@ -0,0 +1,41 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceIndices_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceIndices,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_indices.test", "id", "terraform-test-indices-*"),
+					resource.TestCheckResourceAttr("data.elasticsearch_indices.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_indices.test", "names.0", "terraform-test-indices-000001"),
+					resource.TestCheckResourceAttr("data.elasticsearch_indices.test", "indices.0.status", "open"),
+					resource.TestCheckResourceAttr("data.elasticsearch_indices.test", "indices.0.docs_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceIndices = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-indices-000001"
+  number_of_shards = 1
+  number_of_replicas = 0
+}
+
+data "elasticsearch_indices" "test" {
+  pattern = "${element(list("terraform-test-indices-*", elasticsearch_index.test.id), 0)}"
+}
+`
@@ -0,0 +1,223 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+type nodesInfoResponse struct {
+	ClusterName string `json:"cluster_name"`
+	Nodes       map[string]struct {
+		Name    string   `json:"name"`
+		Host    string   `json:"host"`
+		IP      string   `json:"ip"`
+		Version string   `json:"version"`
+		Roles   []string `json:"roles"`
+		JVM     struct {
+			Mem struct {
+				HeapMaxInBytes int64 `json:"heap_max_in_bytes"`
+			} `json:"mem"`
+		} `json:"jvm"`
+	} `json:"nodes"`
+}
+
+type nodesStatsResponse struct {
+	Nodes map[string]struct {
+		JVM struct {
+			Mem struct {
+				HeapUsedInBytes int64 `json:"heap_used_in_bytes"`
+				HeapUsedPercent int   `json:"heap_used_percent"`
+			} `json:"mem"`
+		} `json:"jvm"`
+	} `json:"nodes"`
+}
+
+func dataSourceElasticsearchNodes() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_nodes` can be used to retrieve the nodes of the provider's current elasticsearch cluster, e.g. to size the replicas of an index after the number of data nodes, optionally filtered by role and along with their JVM heap usage.",
+		Read:        dataSourceElasticsearchNodesRead,
+
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only retrieve the nodes with this role, e.g. `master`, `data`, `ingest` or `data_hot` on ES >= 7.10",
+			},
+			"include_stats": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to also retrieve the heap usage of the nodes, from the nodes stats API",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The nodes, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"heap_max_in_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"heap_used_in_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"heap_used_percent": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the nodes, sorted by name",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchNodesRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		role  = d.Get("role").(string)
+		info  nodesInfoResponse
+		stats nodesStatsResponse
+	)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if err := nodesRequest(esClient, "/_nodes/_all/jvm", &info); err != nil {
+		return err
+	}
+	if d.Get("include_stats").(bool) {
+		if err := nodesRequest(esClient, "/_nodes/_all/stats/jvm", &stats); err != nil {
+			return err
+		}
+	}
+
+	nodes := make([]map[string]interface{}, 0, len(info.Nodes))
+	for id, node := range info.Nodes {
+		if role != "" && !hasNodeRole(node.Roles, role) {
+			continue
+		}
+		nodeStats := stats.Nodes[id]
+		nodes = append(nodes, map[string]interface{}{
+			"id":                 id,
+			"name":               node.Name,
+			"host":               node.Host,
+			"ip":                 node.IP,
+			"version":            node.Version,
+			"roles":              node.Roles,
+			"heap_max_in_bytes":  node.JVM.Mem.HeapMaxInBytes,
+			"heap_used_in_bytes": nodeStats.JVM.Mem.HeapUsedInBytes,
+			"heap_used_percent":  nodeStats.JVM.Mem.HeapUsedPercent,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i]["name"].(string) < nodes[j]["name"].(string)
+	})
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node["name"].(string))
+	}
+
+	d.SetId(info.ClusterName)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("nodes", nodes)
+	ds.set("names", names)
+	return ds.err
+}
+
+func hasNodeRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// nodesRequest gets a nodes info or stats path, which are alike across
+// versions
+func nodesRequest(esClient interface{}, path string, v interface{}) error {
+	var (
+		ctx  = context.Background()
+		body json.RawMessage
+		err  error
+	)
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(ctx, "GET", path, nil, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, body)
+	}
+	return nil
+}
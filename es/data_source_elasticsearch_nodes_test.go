@@ -0,0 +1,47 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceNodes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceNodes,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.elasticsearch_nodes.test", "id"),
+					resource.TestMatchResourceAttr("data.elasticsearch_nodes.test", "nodes.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_nodes.test", "nodes.0.name"),
+					resource.TestMatchResourceAttr("data.elasticsearch_nodes.test", "nodes.0.version", regexp.MustCompile(`^\d+\.\d+\.\d+`)),
+					resource.TestMatchResourceAttr("data.elasticsearch_nodes.test", "nodes.0.heap_max_in_bytes", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestMatchResourceAttr("data.elasticsearch_nodes.test", "nodes.0.heap_used_in_bytes", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestMatchResourceAttr("data.elasticsearch_nodes.data", "names.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr("data.elasticsearch_nodes.data", "nodes.0.heap_used_in_bytes", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_nodes.none", "nodes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceNodes = `
+data "elasticsearch_nodes" "test" {
+  include_stats = true
+}
+
+data "elasticsearch_nodes" "data" {
+  role = "data"
+}
+
+data "elasticsearch_nodes" "none" {
+  role = "terraform-test"
+}
+`
@@ -0,0 +1,242 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var resolveIndexMinimalVersion, _ = version.NewVersion("7.9.0")
+
+type resolveIndexResponse struct {
+	Indices []struct {
+		Name       string   `json:"name"`
+		Aliases    []string `json:"aliases"`
+		Attributes []string `json:"attributes"`
+		DataStream string   `json:"data_stream"`
+	} `json:"indices"`
+	Aliases []struct {
+		Name    string   `json:"name"`
+		Indices []string `json:"indices"`
+	} `json:"aliases"`
+	DataStreams []struct {
+		Name           string   `json:"name"`
+		BackingIndices []string `json:"backing_indices"`
+		TimestampField string   `json:"timestamp_field"`
+	} `json:"data_streams"`
+}
+
+type simulateIndexResponse struct {
+	Template    json.RawMessage `json:"template"`
+	Overlapping []struct {
+		Name string `json:"name"`
+	} `json:"overlapping"`
+}
+
+func dataSourceElasticsearchResolveIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_resolve_index` can be used to resolve the indices, aliases and data streams matching a name or pattern, and the composable index template which applies to a new index of that name (ES >= 7.9).",
+		Read:        dataSourceElasticsearchResolveIndexRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "An index, alias or data stream name or wildcard pattern, e.g. `logs-*`",
+			},
+			"expand_wildcards": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma separated list of the index states wildcards are expanded to, e.g. `open`, `closed`, `hidden`, `all` or `none`",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching indices",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"aliases": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"attributes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"data_stream": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"aliases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching aliases",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"indices": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"data_streams": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching data streams",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"backing_indices": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"timestamp_field": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"template": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON of the settings, mappings and aliases a new index of this name would be created with by the composable index templates, only set if `name` is not a pattern",
+			},
+			"overlapping_templates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the lower priority composable index templates which also match a new index of this name, only set if `name` is not a pattern",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchResolveIndexRead(d *schema.ResourceData, m interface{}) error {
+	var (
+		name      = d.Get("name").(string)
+		resolved  resolveIndexResponse
+		simulated simulateIndexResponse
+	)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("_resolve/index endpoint only available from ElasticSearch >= 7.9, got version < 7.0.0")
+	}
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(resolveIndexMinimalVersion) {
+		return fmt.Errorf("_resolve/index endpoint only available from ElasticSearch >= 7.9, got version %s", elasticVersion.String())
+	}
+
+	params := url.Values{}
+	if expandWildcards := d.Get("expand_wildcards").(string); expandWildcards != "" {
+		params.Set("expand_wildcards", expandWildcards)
+	}
+	if err := elastic7ResolveIndexRequest(client, "GET", "/_resolve/index/{name}", name, params, &resolved); err != nil {
+		return err
+	}
+
+	// only a concrete name can be simulated
+	if !strings.ContainsAny(name, "*,") {
+		err := elastic7ResolveIndexRequest(client, "POST", "/_index_template/_simulate_index/{name}", name, nil, &simulated)
+		if err != nil && !elastic7.IsNotFound(err) {
+			return err
+		}
+	}
+
+	indices := make([]map[string]interface{}, 0, len(resolved.Indices))
+	for _, index := range resolved.Indices {
+		indices = append(indices, map[string]interface{}{
+			"name":        index.Name,
+			"aliases":     index.Aliases,
+			"attributes":  index.Attributes,
+			"data_stream": index.DataStream,
+		})
+	}
+	aliases := make([]map[string]interface{}, 0, len(resolved.Aliases))
+	for _, alias := range resolved.Aliases {
+		aliases = append(aliases, map[string]interface{}{
+			"name":    alias.Name,
+			"indices": alias.Indices,
+		})
+	}
+	dataStreams := make([]map[string]interface{}, 0, len(resolved.DataStreams))
+	for _, dataStream := range resolved.DataStreams {
+		dataStreams = append(dataStreams, map[string]interface{}{
+			"name":            dataStream.Name,
+			"backing_indices": dataStream.BackingIndices,
+			"timestamp_field": dataStream.TimestampField,
+		})
+	}
+	overlapping := make([]string, 0, len(simulated.Overlapping))
+	for _, template := range simulated.Overlapping {
+		overlapping = append(overlapping, template.Name)
+	}
+
+	d.SetId(name)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("indices", indices)
+	ds.set("aliases", aliases)
+	ds.set("data_streams", dataStreams)
+	ds.set("template", string(simulated.Template))
+	ds.set("overlapping_templates", overlapping)
+	return ds.err
+}
+
+func elastic7ResolveIndexRequest(client *elastic7.Client, method, template, name string, params url.Values, v interface{}) error {
+	path, err := uritemplates.Expand(template, map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for %s: %+v", name, err)
+	}
+
+	res, err := client.PerformRequest(context.Background(), elastic7.PerformRequestOptions{
+		Method: method,
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(res.Body, v); err != nil {
+		return fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, res.Body)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func TestAccElasticsearchDataSourceResolveIndex_basic(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	var allowed bool
+	if client, ok := esClient.(*elastic7.Client); ok {
+		elasticVersion, err := elastic7GetVersion(client)
+		allowed = err == nil && !elasticVersion.LessThan(resolveIndexMinimalVersion)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("_resolve/index endpoint only supported on ES >= 7.9")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceResolveIndex,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.pattern", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.pattern", "indices.0.name", "terraform-test-resolve-000001"),
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.pattern", "indices.0.aliases.0", "terraform-test-resolve"),
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.pattern", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.pattern", "template", ""),
+					resource.TestMatchResourceAttr("data.elasticsearch_resolve_index.name", "template", regexp.MustCompile(`"number_of_shards":"2"`)),
+					resource.TestCheckResourceAttr("data.elasticsearch_resolve_index.name", "indices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceResolveIndex = `
+resource "elasticsearch_composable_index_template" "test" {
+  name = "terraform-test-resolve"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-resolve-*"],
+  "template": {
+    "settings": {
+      "index": {
+        "number_of_shards": 2
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-resolve-000001"
+  number_of_shards = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-resolve" = {}
+  })
+}
+
+data "elasticsearch_resolve_index" "pattern" {
+  name = "${element(list("terraform-test-resolve-*", elasticsearch_index.test.id), 0)}"
+}
+
+data "elasticsearch_resolve_index" "name" {
+  name = "${element(list("terraform-test-resolve-000002", elasticsearch_composable_index_template.test.id), 0)}"
+}
+`
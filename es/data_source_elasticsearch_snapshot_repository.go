@@ -0,0 +1,86 @@
+package es
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// snapshotRepositorySensitiveSettings are the repository credentials of the
+// cloud plugins, which are never exposed by the data source
+var snapshotRepositorySensitiveSettings = []string{
+	"access_key",
+	"secret_key",
+	"session_token",
+	"key",
+	"credentials_file",
+}
+
+func dataSourceElasticsearchSnapshotRepository() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_snapshot_repository` can be used to retrieve the type and settings of an existing snapshot repository.",
+		Read:        dataSourceElasticsearchSnapshotRepositoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the snapshot repository",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the repository, e.g. `fs` or `s3`",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The settings of the repository, excluding credentials",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchSnapshotRepositoryRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+
+	var repositoryType string
+	var settings map[string]interface{}
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		repositoryType, settings, err = elastic7SnapshotGetRepository(client, name)
+	case *elastic6.Client:
+		repositoryType, settings, err = elastic6SnapshotGetRepository(client, name)
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		repositoryType, settings, err = elastic5SnapshotGetRepository(elastic5Client, name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	flattened := flattenMap(settings)
+	for _, key := range snapshotRepositorySensitiveSettings {
+		delete(flattened, key)
+	}
+	for key, value := range flattened {
+		flattened[key] = fmt.Sprintf("%v", value)
+	}
+
+	d.SetId(name)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("type", repositoryType)
+	ds.set("settings", flattened)
+	return ds.err
+}
@@ -0,0 +1,42 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceSnapshotRepository_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchSnapshotRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceSnapshotRepository,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository.test", "id", "terraform-test"),
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository.test", "type", "fs"),
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository.test", "settings.location", "/tmp/elasticsearch"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceSnapshotRepository = `
+resource "elasticsearch_snapshot_repository" "test" {
+  name = "terraform-test"
+  type = "fs"
+
+  settings = {
+    location = "/tmp/elasticsearch"
+  }
+}
+
+data "elasticsearch_snapshot_repository" "test" {
+  name = elasticsearch_snapshot_repository.test.name
+}
+`
@@ -0,0 +1,65 @@
+package es
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceElasticsearchWriteIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_write_index` can be used to resolve an alias, e.g. the rollover alias of an ILM series, to its current write index.",
+		Read:        dataSourceElasticsearchWriteIndexRead,
+
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the alias",
+			},
+			"index": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The write index of the alias, either the index marked with `is_write_index` or the only index of the alias",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of all the indices of the alias",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchWriteIndexRead(d *schema.ResourceData, m interface{}) error {
+	alias := d.Get("alias").(string)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	indices, writeIndex, err := getAliasIndices(esClient, alias)
+	if err != nil {
+		return err
+	}
+
+	if len(indices) == 0 {
+		return fmt.Errorf("alias %s not found", alias)
+	}
+	// an alias of a single index writes to it without is_write_index
+	if writeIndex == "" && len(indices) == 1 {
+		writeIndex = indices[0]
+	}
+	if writeIndex == "" {
+		return fmt.Errorf("alias %s has no write index, it points to %s", alias, strings.Join(indices, ", "))
+	}
+
+	d.SetId(alias)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("index", writeIndex)
+	ds.set("indices", indices)
+	return ds.err
+}
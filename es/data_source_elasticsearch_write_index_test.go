@@ -0,0 +1,53 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceWriteIndex_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchDataSourceWriteIndexMissing,
+				ExpectError: regexp.MustCompile("alias terraform-test-missing-alias not found"),
+			},
+			{
+				Config: testAccElasticsearchDataSourceWriteIndex,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_write_index.test", "index", "terraform-test-write-index"),
+					resource.TestCheckResourceAttr("data.elasticsearch_write_index.test", "indices.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticsearch_write_index.test", "indices.0", "terraform-test-write-index"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceWriteIndexMissing = `
+data "elasticsearch_write_index" "test" {
+  alias = "terraform-test-missing-alias"
+}
+`
+
+var testAccElasticsearchDataSourceWriteIndex = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-write-index"
+  number_of_shards = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-write-alias" = {}
+  })
+}
+
+data "elasticsearch_write_index" "test" {
+  alias = "${element(list("terraform-test-write-alias", elasticsearch_index.test.id), 0)}"
+}
+`
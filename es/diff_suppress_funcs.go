@@ -51,6 +51,64 @@ func diffSuppressComposableIndexTemplate(k, old, new string, d *schema.ResourceD
 	return reflect.DeepEqual(oo, no)
 }
 
+// diffSuppressIndexMappings compares mappings regardless of whether they're
+// typed, as ES < 7 returns them, or typeless, as ES >= 7 returns them
+func diffSuppressIndexMappings(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		oo = normalizeIndexMappings(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		no = normalizeIndexMappings(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressIndexSettings(k, old, new string, d *schema.ResourceData) bool {
+	oldSettings, err := indexSettingsFromJSON(old)
+	if err != nil {
+		return false
+	}
+	newSettings, err := indexSettingsFromJSON(new)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldSettings, newSettings)
+}
+
+func diffSuppressIndexAliases(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeIndexAliases(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeIndexAliases(nm)
+		if om, ok := oo.(map[string]interface{}); ok {
+			ignoreUnsetIndexAliasesFields(om, nm)
+		}
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
 func diffSuppressDestination(k, old, new string, d *schema.ResourceData) bool {
 	var oo, no interface{}
 	if err := json.Unmarshal([]byte(old), &oo); err != nil {
@@ -184,3 +242,10 @@ func diffSuppressLicense(k, old, new string, d *schema.ResourceData) bool {
 	}
 	return reflect.DeepEqual(oldObj, newObj)
 }
+
+// diffSuppressIndexReplicas ignores number_of_replicas drift while
+// auto_expand_replicas is set, as ES then computes the replicas itself
+func diffSuppressIndexReplicas(k, old, new string, d *schema.ResourceData) bool {
+	autoExpand := d.Get("auto_expand_replicas").(string)
+	return autoExpand != "" && autoExpand != "false"
+}
@@ -1,7 +1,13 @@
 package es
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type withHeader struct {
@@ -24,3 +30,116 @@ func (h withHeader) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	return h.rt.RoundTrip(req)
 }
+
+// compatibleMediaTypes maps the media types sent by the ES 7 client to the
+// ones requesting the REST API compatibility of ES 8 with ES 7
+var compatibleMediaTypes = map[string]string{
+	"application/json":     "application/vnd.elasticsearch+json;compatible-with=7",
+	"application/x-ndjson": "application/vnd.elasticsearch+x-ndjson;compatible-with=7",
+}
+
+type withCompatibility struct {
+	rt http.RoundTripper
+}
+
+// WithCompatibility requests ES 7 compatible responses, so the ES 7 client can
+// be used against ES 8
+func WithCompatibility(rt http.RoundTripper) withCompatibility {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return withCompatibility{rt: rt}
+}
+
+func (c withCompatibility) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Accept", compatibleMediaTypes["application/json"])
+	if mediaType, ok := compatibleMediaTypes[req.Header.Get("Content-Type")]; ok {
+		req.Header.Set("Content-Type", mediaType)
+	}
+
+	return c.rt.RoundTrip(req)
+}
+
+// tooManyRequestsMaxBackoff bounds the exponential backoff of requests
+// rejected with 429 Too Many Requests, unless the cluster asks for longer
+var tooManyRequestsMaxBackoff = 30 * time.Second
+
+type withTooManyRequestsRetry struct {
+	rt         http.RoundTripper
+	maxRetries int
+}
+
+// WithTooManyRequestsRetry retries requests rejected with 429 Too Many
+// Requests, e.g. when the cluster is under write pressure, waiting as long as
+// the Retry-After header asks or backing off exponentially from 1s
+func WithTooManyRequestsRetry(rt http.RoundTripper, maxRetries int) withTooManyRequestsRetry {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return withTooManyRequestsRetry{rt: rt, maxRetries: maxRetries}
+}
+
+func (r withTooManyRequestsRetry) RoundTrip(req *http.Request) (*http.Response, error) {
+	// buffer the body so that it can be sent again
+	if req.Body != nil && req.GetBody == nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := r.rt.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= r.maxRetries {
+			return resp, err
+		}
+
+		wait := tooManyRequestsBackoff(attempt, resp.Header.Get("Retry-After"))
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		log.Printf("[WARN] %s %s was rejected with 429 Too Many Requests, retrying in %s (%d/%d)", req.Method, req.URL.Path, wait, attempt+1, r.maxRetries)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// tooManyRequestsBackoff returns how long to wait before retrying, honoring
+// the Retry-After header in seconds or as an HTTP date
+func tooManyRequestsBackoff(attempt int, retryAfter string) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+
+	wait := time.Second << uint(attempt)
+	if wait <= 0 || wait > tooManyRequestsMaxBackoff {
+		wait = tooManyRequestsMaxBackoff
+	}
+	return wait
+}
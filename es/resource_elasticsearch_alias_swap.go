@@ -0,0 +1,157 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchAliasSwap() *schema.Resource {
+	return &schema.Resource{
+		Description: "Atomically moves an alias from an old index to a new one, e.g. for a blue/green reindex, in a single `_aliases` request so that the alias never points to neither or both of them. Changing `new_index` moves the alias again from the previous new index, destroying the resource only removes it from the state and leaves the alias in place. An alias declared in the `aliases` of the `elasticsearch_index` it is moved from is then ignored by that index rather than restored. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-aliases.html) for more details.",
+		Create:      resourceElasticsearchAliasSwapCreate,
+		Read:        resourceElasticsearchAliasSwapRead,
+		Update:      resourceElasticsearchAliasSwapUpdate,
+		Delete:      resourceElasticsearchAliasSwapDelete,
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the alias to move",
+			},
+			"old_index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index the alias is moved from, which must be an index of the alias",
+			},
+			"new_index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index the alias is moved to",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchAliasSwapCreate(d *schema.ResourceData, meta interface{}) error {
+	alias := d.Get("alias").(string)
+	if err := swapElasticsearchAlias(meta, alias, d.Get("old_index").(string), d.Get("new_index").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(alias)
+	return resourceElasticsearchAliasSwapRead(d, meta)
+}
+
+func resourceElasticsearchAliasSwapRead(d *schema.ResourceData, meta interface{}) error {
+	alias := d.Id()
+	newIndex := d.Get("new_index").(string)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	indices, _, err := getAliasIndices(esClient, alias)
+	if err != nil {
+		return err
+	}
+
+	for _, index := range indices {
+		if index == newIndex {
+			return d.Set("alias", alias)
+		}
+	}
+
+	// the alias was moved outside of the provider, it is moved again from the
+	// index it points to
+	log.Printf("[WARN] Alias (%s) doesn't point to %s but to %v", alias, newIndex, indices)
+	current := ""
+	if len(indices) == 1 {
+		current = indices[0]
+	}
+	ds := &resourceDataSetter{d: d}
+	ds.set("alias", alias)
+	ds.set("new_index", current)
+	return ds.err
+}
+
+func resourceElasticsearchAliasSwapUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("new_index") {
+		o, n := d.GetChange("new_index")
+		if err := swapElasticsearchAlias(meta, d.Id(), o.(string), n.(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchAliasSwapRead(d, meta)
+}
+
+// The alias is left in place, moving it away would leave it without an index
+func resourceElasticsearchAliasSwapDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// swapElasticsearchAlias removes an alias from an index and adds it to another
+// one in the same request, which ES applies atomically, or only adds it if
+// there is no index to remove it from
+func swapElasticsearchAlias(meta interface{}, alias, oldIndex, newIndex string) error {
+	var (
+		ctx          = context.Background()
+		acknowledged bool
+	)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		service := client.Alias()
+		if oldIndex != "" {
+			service = service.Action(elastic7.NewAliasRemoveAction(alias).Index(oldIndex))
+		}
+		var res *elastic7.AliasResult
+		res, err = service.Action(elastic7.NewAliasAddAction(alias).Index(newIndex)).Do(ctx)
+		if err == nil {
+			acknowledged = res.Acknowledged
+		}
+	case *elastic6.Client:
+		service := client.Alias()
+		if oldIndex != "" {
+			service = service.Action(elastic6.NewAliasRemoveAction(alias).Index(oldIndex))
+		}
+		var res *elastic6.AliasResult
+		res, err = service.Action(elastic6.NewAliasAddAction(alias).Index(newIndex)).Do(ctx)
+		if err == nil {
+			acknowledged = res.Acknowledged
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		service := elastic5Client.Alias()
+		if oldIndex != "" {
+			service = service.Action(elastic5.NewAliasRemoveAction(alias).Index(oldIndex))
+		}
+		var res *elastic5.AliasResult
+		res, err = service.Action(elastic5.NewAliasAddAction(alias).Index(newIndex)).Do(ctx)
+		if err == nil {
+			acknowledged = res.Acknowledged
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error moving alias %s from %s to %s: %+v", alias, oldIndex, newIndex, err)
+	}
+	if !acknowledged {
+		return fmt.Errorf("moving alias %s from %s to %s was not acknowledged", alias, oldIndex, newIndex)
+	}
+	return nil
+}
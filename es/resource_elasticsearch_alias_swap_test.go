@@ -0,0 +1,136 @@
+package es
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchAliasSwap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchAliasSwapIndices,
+			},
+			{
+				Config:      testAccElasticsearchAliasSwapIndices + fmt.Sprintf(testAccElasticsearchAliasSwap, "terraform-test-green"),
+				ExpectError: regexp.MustCompile("error moving alias terraform-test-alias from terraform-test-blue to terraform-test-green"),
+			},
+			{
+				PreConfig: func() {
+					if err := swapElasticsearchAlias(testAccProvider.Meta(), "terraform-test-alias", "", "terraform-test-blue"); err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccElasticsearchAliasSwapIndices + fmt.Sprintf(testAccElasticsearchAliasSwap, "terraform-test-green"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_alias_swap.test", "id", "terraform-test-alias"),
+					checkElasticsearchAliasIndices("terraform-test-alias", []string{"terraform-test-green"}),
+				),
+			},
+			{
+				// moving the alias back swaps it from the previous new index
+				Config: testAccElasticsearchAliasSwapIndices + fmt.Sprintf(testAccElasticsearchAliasSwap, "terraform-test-blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_alias_swap.test", "new_index", "terraform-test-blue"),
+					checkElasticsearchAliasIndices("terraform-test-alias", []string{"terraform-test-blue"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchAliasSwap_declaredAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchAliasSwapDeclaredAlias,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchAliasIndices("terraform-test-declared-alias", []string{"terraform-test-green"}),
+				),
+			},
+			{
+				// refreshing the index the alias was moved from doesn't
+				// replace it
+				Config:   testAccElasticsearchAliasSwapDeclaredAlias,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func checkElasticsearchAliasIndices(alias string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		esClient, err := getClient(testAccProvider.Meta().(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		indices, _, err := getAliasIndices(esClient, alias)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(indices, expected) {
+			return fmt.Errorf("expected alias %s to point to %v, got %v", alias, expected, indices)
+		}
+		return nil
+	}
+}
+
+var testAccElasticsearchAliasSwapIndices = `
+resource "elasticsearch_index" "blue" {
+  name = "terraform-test-blue"
+  number_of_shards = 1
+  number_of_replicas = 1
+}
+
+resource "elasticsearch_index" "green" {
+  name = "terraform-test-green"
+  number_of_shards = 1
+  number_of_replicas = 1
+}
+`
+
+var testAccElasticsearchAliasSwap = `
+resource "elasticsearch_alias_swap" "test" {
+  alias     = "terraform-test-alias"
+  old_index = elasticsearch_index.blue.name
+  new_index = "%s"
+
+  depends_on = [elasticsearch_index.green]
+}
+`
+
+var testAccElasticsearchAliasSwapDeclaredAlias = `
+resource "elasticsearch_index" "blue" {
+  name = "terraform-test-blue"
+  number_of_shards = 1
+  number_of_replicas = 1
+  aliases = <<EOF
+{
+  "terraform-test-declared-alias": {}
+}
+EOF
+}
+
+resource "elasticsearch_index" "green" {
+  name = "terraform-test-green"
+  number_of_shards = 1
+  number_of_replicas = 1
+}
+
+resource "elasticsearch_alias_swap" "test" {
+  alias     = "terraform-test-declared-alias"
+  old_index = elasticsearch_index.blue.name
+  new_index = elasticsearch_index.green.name
+}
+`
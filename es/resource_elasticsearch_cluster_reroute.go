@@ -0,0 +1,103 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchClusterReroute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Issues cluster reroute commands, e.g. to move a shard or allocate a replica. The commands are executed once on create, changing them issues them again, destroying the resource only removes it from the state. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-reroute.html) for more details.",
+		Create:      resourceElasticsearchClusterRerouteCreate,
+		Read:        resourceElasticsearchClusterRerouteRead,
+		Delete:      resourceElasticsearchClusterRerouteDelete,
+		Schema: map[string]*schema.Schema{
+			"commands": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "[]",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON array of commands, e.g. `[{\"move\": {\"index\": \"logs\", \"shard\": 0, \"from_node\": \"node1\", \"to_node\": \"node2\"}}]`, supporting `move`, `cancel`, `allocate_replica`, `allocate_stale_primary` and `allocate_empty_primary`",
+			},
+			"retry_failed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Retry the allocation of shards which failed to allocate too many times in a row",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchClusterRerouteCreate(d *schema.ResourceData, meta interface{}) error {
+	var commands []interface{}
+	if err := json.Unmarshal([]byte(d.Get("commands").(string)), &commands); err != nil {
+		return fmt.Errorf("fail to unmarshal commands: %v", err)
+	}
+
+	body := map[string]interface{}{
+		"commands": commands,
+	}
+	params := url.Values{}
+	params.Set("retry_failed", strconv.FormatBool(d.Get("retry_failed").(bool)))
+	if masterTimeout := meta.(*ProviderConf).masterTimeout; masterTimeout != "" {
+		params.Set("master_timeout", masterTimeout)
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "POST",
+			Path:   "/_cluster/reroute",
+			Params: params,
+			Body:   body,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "POST",
+			Path:   "/_cluster/reroute",
+			Params: params,
+			Body:   body,
+		})
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		_, err = elastic5Client.PerformRequest(context.TODO(), "POST", "/_cluster/reroute", params, body)
+	}
+
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Rerouted the cluster with %d commands", len(commands))
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+// The commands are executed once, there is no state to read back
+func resourceElasticsearchClusterRerouteRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceElasticsearchClusterRerouteDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,48 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchClusterReroute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchClusterReroute,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticsearch_cluster_reroute.test", "id"),
+					resource.TestCheckResourceAttr("elasticsearch_cluster_reroute.test", "retry_failed", "true"),
+				),
+			},
+			{
+				Config:      testAccElasticsearchClusterRerouteInvalid,
+				ExpectError: regexp.MustCompile("terraform-test-missing"),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchClusterReroute = `
+resource "elasticsearch_cluster_reroute" "test" {
+  retry_failed = true
+}
+`
+
+var testAccElasticsearchClusterRerouteInvalid = `
+resource "elasticsearch_cluster_reroute" "test" {
+  commands = jsonencode([
+    {
+      "cancel" = {
+        "index" = "terraform-test-missing"
+        "shard" = 0
+        "node"  = "missing"
+      }
+    }
+  ])
+}
+`
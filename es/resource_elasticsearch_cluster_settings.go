@@ -0,0 +1,306 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// clusterSettingsKeys maps the attributes of the cluster settings resource to
+// the persistent settings they manage
+var clusterSettingsKeys = map[string]string{
+	"cluster_routing_allocation_enable":                     "cluster.routing.allocation.enable",
+	"cluster_routing_allocation_disk_threshold_enabled":     "cluster.routing.allocation.disk.threshold_enabled",
+	"cluster_routing_allocation_disk_watermark_low":         "cluster.routing.allocation.disk.watermark.low",
+	"cluster_routing_allocation_disk_watermark_high":        "cluster.routing.allocation.disk.watermark.high",
+	"cluster_routing_allocation_disk_watermark_flood_stage": "cluster.routing.allocation.disk.watermark.flood_stage",
+}
+
+// the disk watermarks, from the lowest disk usage to the highest
+var diskWatermarks = []string{
+	"cluster_routing_allocation_disk_watermark_low",
+	"cluster_routing_allocation_disk_watermark_high",
+	"cluster_routing_allocation_disk_watermark_flood_stage",
+}
+
+var (
+	diskWatermarkPercentageRegexp = regexp.MustCompile(`^(\d+(\.\d+)?)%$`)
+	diskWatermarkRatioRegexp      = regexp.MustCompile(`^(0|1)?(\.\d+)?$`)
+	diskWatermarkBytesRegexp      = regexp.MustCompile(`^(?i)(\d+(\.\d+)?)(b|kb|mb|gb|tb|pb)$`)
+	diskWatermarkByteUnits        = map[string]float64{"b": 1, "kb": 1 << 10, "mb": 1 << 20, "gb": 1 << 30, "tb": 1 << 40, "pb": 1 << 50}
+)
+
+func resourceElasticsearchClusterSettings() *schema.Resource {
+	diskWatermarkSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateDiskWatermark,
+			Description:  description + " Either a percentage or a ratio of disk usage, e.g. `85%` or `0.85`, or an amount of free disk space, e.g. `50gb`, like the other watermarks.",
+		}
+	}
+
+	return &schema.Resource{
+		Description:   "Manages the most common persistent allocation deciders of the cluster, the disk watermarks and whether shards can be allocated, as typed attributes. Only the settings of the configured attributes are read and updated, destroying the resource resets them to their defaults. The cluster should only have one of these resources. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/modules-cluster.html) for more details.",
+		Create:        resourceElasticsearchClusterSettingsCreate,
+		Read:          resourceElasticsearchClusterSettingsRead,
+		Update:        resourceElasticsearchClusterSettingsUpdate,
+		Delete:        resourceElasticsearchClusterSettingsDelete,
+		CustomizeDiff: resourceElasticsearchClusterSettingsCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"cluster_routing_allocation_enable": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"all", "primaries", "new_primaries", "none"}, false),
+				Description:  "Which shards can be allocated, `all`, `primaries`, `new_primaries` or `none`, e.g. to prevent replicas from being allocated during a rolling restart.",
+			},
+			"cluster_routing_allocation_disk_threshold_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the disk allocation decider, which applies the disk watermarks, is enabled.",
+			},
+			"cluster_routing_allocation_disk_watermark_low":         diskWatermarkSchema("The disk usage above which no shards are allocated to a node."),
+			"cluster_routing_allocation_disk_watermark_high":        diskWatermarkSchema("The disk usage above which shards are relocated away from a node."),
+			"cluster_routing_allocation_disk_watermark_flood_stage": diskWatermarkSchema("The disk usage above which the indices with a shard on a node are made read-only, with `index.blocks.read_only_allow_delete` (ES >= 6.0)."),
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchClusterSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	settings := make(map[string]interface{})
+	for name, key := range clusterSettingsKeys {
+		if value, ok := d.GetOkExists(name); ok {
+			settings[key] = value
+		}
+	}
+	if err := putPersistentClusterSettings(meta, settings); err != nil {
+		return err
+	}
+
+	d.SetId("cluster_settings")
+	return resourceElasticsearchClusterSettingsRead(d, meta)
+}
+
+func resourceElasticsearchClusterSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	params := url.Values{}
+	params.Set("flat_settings", "true")
+	body, err := clusterSettingsRequest(meta, "GET", params, nil)
+	if err != nil {
+		return err
+	}
+	var settings struct {
+		Persistent map[string]interface{} `json:"persistent"`
+	}
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return fmt.Errorf("error unmarshalling cluster settings body: %+v: %+v", err, body)
+	}
+
+	// Only read the settings of the configured attributes, or all of them on
+	// import, so that the other ones can be managed elsewhere
+	imported := true
+	for name := range clusterSettingsKeys {
+		if _, ok := d.GetOkExists(name); ok {
+			imported = false
+		}
+	}
+
+	ds := &resourceDataSetter{d: d}
+	for name, key := range clusterSettingsKeys {
+		if _, ok := d.GetOkExists(name); !ok && !imported {
+			continue
+		}
+		value, ok := settings.Persistent[key].(string)
+		if !ok {
+			ds.set(name, nil)
+			continue
+		}
+		if _, isBool := d.Get(name).(bool); isBool {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				log.Printf("[INFO] resourceElasticsearchClusterSettingsRead: %+v", err)
+				continue
+			}
+			ds.set(name, enabled)
+		} else {
+			ds.set(name, value)
+		}
+	}
+	return ds.err
+}
+
+func resourceElasticsearchClusterSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	// null out removed settings so the cluster falls back to its defaults
+	settings := make(map[string]interface{})
+	for name, key := range clusterSettingsKeys {
+		if !d.HasChange(name) {
+			continue
+		}
+		if value, ok := d.GetOkExists(name); ok {
+			settings[key] = value
+		} else {
+			settings[key] = nil
+		}
+	}
+	if err := putPersistentClusterSettings(meta, settings); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchClusterSettingsRead(d, meta)
+}
+
+func resourceElasticsearchClusterSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	settings := make(map[string]interface{})
+	for name, key := range clusterSettingsKeys {
+		if _, ok := d.GetOkExists(name); ok {
+			settings[key] = nil
+		}
+	}
+	return putPersistentClusterSettings(meta, settings)
+}
+
+// resourceElasticsearchClusterSettingsCustomizeDiff checks that the disk
+// watermarks are all relative or all absolute, and ordered, as ES only
+// rejects them when they're applied
+func resourceElasticsearchClusterSettingsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	var (
+		previous      string
+		previousValue float64
+		relative      bool
+	)
+	for _, name := range diskWatermarks {
+		raw, ok := d.GetOk(name)
+		if !ok {
+			continue
+		}
+		value, isRelative, err := parseDiskWatermark(raw.(string))
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if previous != "" {
+			if isRelative != relative {
+				return fmt.Errorf("%s and %s must both be a disk usage or an amount of free disk space", previous, name)
+			}
+			// the higher watermarks have more disk usage, or less free space
+			if isRelative && value < previousValue {
+				return fmt.Errorf("%s (%s) must be at least %s (%s)", name, raw, previous, d.Get(previous))
+			}
+			if !isRelative && value > previousValue {
+				return fmt.Errorf("%s (%s) must be at most %s (%s)", name, raw, previous, d.Get(previous))
+			}
+		}
+		previous, previousValue, relative = name, value, isRelative
+	}
+	return nil
+}
+
+func validateDiskWatermark(v interface{}, k string) (ws []string, errors []error) {
+	if _, _, err := parseDiskWatermark(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %v", k, err))
+	}
+	return
+}
+
+// parseDiskWatermark returns the disk usage ratio of a relative watermark, or
+// the amount of free bytes of an absolute one, and whether it is relative
+func parseDiskWatermark(watermark string) (float64, bool, error) {
+	if m := diskWatermarkPercentageRegexp.FindStringSubmatch(watermark); m != nil {
+		percentage, _ := strconv.ParseFloat(m[1], 64)
+		if percentage > 100 {
+			return 0, false, fmt.Errorf("%s is above 100%%", watermark)
+		}
+		return percentage / 100, true, nil
+	}
+	if watermark != "" && watermark != "." && diskWatermarkRatioRegexp.MatchString(watermark) {
+		ratio, _ := strconv.ParseFloat(watermark, 64)
+		if ratio > 1 {
+			return 0, false, fmt.Errorf("%s is above 1.0", watermark)
+		}
+		return ratio, true, nil
+	}
+	if m := diskWatermarkBytesRegexp.FindStringSubmatch(watermark); m != nil {
+		size, _ := strconv.ParseFloat(m[1], 64)
+		return size * diskWatermarkByteUnits[strings.ToLower(m[3])], false, nil
+	}
+	return 0, false, fmt.Errorf("%s is neither a percentage, a ratio nor a byte size, e.g. `85%%`, `0.85` or `50gb`", watermark)
+}
+
+// putPersistentClusterSettings updates persistent cluster settings, resetting
+// the ones with a nil value
+func putPersistentClusterSettings(meta interface{}, settings map[string]interface{}) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	params := url.Values{}
+	if masterTimeout := meta.(*ProviderConf).masterTimeout; masterTimeout != "" {
+		params.Set("master_timeout", masterTimeout)
+	}
+	_, err := clusterSettingsRequest(meta, "PUT", params, map[string]interface{}{
+		"persistent": settings,
+	})
+	return err
+}
+
+// clusterSettingsRequest gets or updates the cluster settings
+func clusterSettingsRequest(meta interface{}, method string, params url.Values, body interface{}) (json.RawMessage, error) {
+	var (
+		ctx     = context.Background()
+		path    = "/_cluster/settings"
+		resBody json.RawMessage
+	)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: method,
+			Path:   path,
+			Params: params,
+			Body:   body,
+		})
+		if err == nil {
+			resBody = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: method,
+			Path:   path,
+			Params: params,
+			Body:   body,
+		})
+		if err == nil {
+			resBody = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(ctx, method, path, params, body)
+		if err == nil {
+			resBody = res.Body
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error requesting %s %s: %+v", method, path, err)
+	}
+	return resBody, nil
+}
@@ -0,0 +1,118 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+)
+
+func TestAccElasticsearchClusterSettings(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	esClient, err := getClient(provider.Meta().(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("The flood stage disk watermark is only supported on ES >= 6")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchClusterSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchClusterSettings, "", "90%", "85%", "95%"),
+				ExpectError: regexp.MustCompile(`cluster_routing_allocation_disk_watermark_high \(85%\) must be at least cluster_routing_allocation_disk_watermark_low \(90%\)`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchClusterSettings, "", "85%", "10gb", "5gb"),
+				ExpectError: regexp.MustCompile("must both be a disk usage or an amount of free disk space"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchClusterSettings, `cluster_routing_allocation_enable = "primaries"`, "85%", "90%", "95%"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_cluster_settings.test", "cluster_routing_allocation_enable", "primaries"),
+					resource.TestCheckResourceAttr("elasticsearch_cluster_settings.test", "cluster_routing_allocation_disk_watermark_low", "85%"),
+					checkElasticsearchClusterSetting("cluster.routing.allocation.enable", "primaries"),
+					checkElasticsearchClusterSetting("cluster.routing.allocation.disk.watermark.high", "90%"),
+					checkElasticsearchClusterSetting("cluster.routing.allocation.disk.watermark.flood_stage", "95%"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchClusterSettings, "", "50gb", "20gb", "10gb"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchClusterSetting("cluster.routing.allocation.enable", ""),
+					checkElasticsearchClusterSetting("cluster.routing.allocation.disk.watermark.low", "50gb"),
+				),
+			},
+		},
+	})
+}
+
+func checkElasticsearchClusterSetting(key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getPersistentClusterSettings()
+		if err != nil {
+			return err
+		}
+
+		actual, _ := settings[key].(string)
+		if actual != expected {
+			return fmt.Errorf("expected %s to be %q got %q", key, expected, actual)
+		}
+		return nil
+	}
+}
+
+func checkElasticsearchClusterSettingsDestroy(s *terraform.State) error {
+	settings, err := getPersistentClusterSettings()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range clusterSettingsKeys {
+		if value, ok := settings[key]; ok {
+			return fmt.Errorf("cluster setting %s still set to %v", key, value)
+		}
+	}
+	return nil
+}
+
+func getPersistentClusterSettings() (map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("flat_settings", "true")
+	body, err := clusterSettingsRequest(testAccProvider.Meta(), "GET", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings struct {
+		Persistent map[string]interface{} `json:"persistent"`
+	}
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return nil, err
+	}
+	return settings.Persistent, nil
+}
+
+var testAccElasticsearchClusterSettings = `
+resource "elasticsearch_cluster_settings" "test" {
+  %s
+  cluster_routing_allocation_disk_watermark_low         = "%s"
+  cluster_routing_allocation_disk_watermark_high        = "%s"
+  cluster_routing_allocation_disk_watermark_flood_stage = "%s"
+}
+`
@@ -125,7 +125,7 @@ func resourceElasticsearchComposableIndexTemplateDelete(d *schema.ResourceData,
 			if elasticVersion.LessThan(minimalVersion) {
 				err = fmt.Errorf("index_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
 			} else {
-				err = elastic7DeleteIndexTemplate(client, id)
+				err = elastic7DeleteIndexTemplate(client, id, meta.(*ProviderConf).masterTimeout)
 			}
 		}
 	default:
@@ -139,8 +139,8 @@ func resourceElasticsearchComposableIndexTemplateDelete(d *schema.ResourceData,
 	return nil
 }
 
-func elastic7DeleteIndexTemplate(client *elastic7.Client, id string) error {
-	_, err := client.IndexDeleteIndexTemplate(id).Do(context.TODO())
+func elastic7DeleteIndexTemplate(client *elastic7.Client, id string, masterTimeout string) error {
+	_, err := client.IndexDeleteIndexTemplate(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
@@ -162,7 +162,7 @@ func resourceElasticsearchPutComposableIndexTemplate(d *schema.ResourceData, met
 			if elasticVersion.LessThan(minimalVersion) {
 				err = fmt.Errorf("index_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
 			} else {
-				err = elastic7PutIndexTemplate(client, name, body, create)
+				err = elastic7PutIndexTemplate(client, name, body, create, meta.(*ProviderConf).masterTimeout)
 			}
 		}
 	default:
@@ -172,7 +172,7 @@ func resourceElasticsearchPutComposableIndexTemplate(d *schema.ResourceData, met
 	return err
 }
 
-func elastic7PutIndexTemplate(client *elastic7.Client, name string, body string, create bool) error {
-	_, err := client.IndexPutIndexTemplate(name).BodyString(body).Create(create).Do(context.TODO())
+func elastic7PutIndexTemplate(client *elastic7.Client, name string, body string, create bool, masterTimeout string) error {
+	_, err := client.IndexPutIndexTemplate(name).BodyString(body).Create(create).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
@@ -0,0 +1,162 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var danglingIndicesMinimalVersion, _ = version.NewVersion("7.9.0")
+
+type danglingIndex struct {
+	IndexName          string   `json:"index_name"`
+	IndexUUID          string   `json:"index_uuid"`
+	CreationDateMillis int64    `json:"creation_date_millis"`
+	NodeIds            []string `json:"node_ids"`
+}
+
+func resourceElasticsearchDanglingIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "Imports or deletes a dangling index, an index whose data is present on a node but which is missing from the cluster state (ES >= 7.9). The action is performed once on create, destroying the resource only removes it from the state. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/indices.html#dangling-indices-api) for more details.",
+		Create:      resourceElasticsearchDanglingIndexCreate,
+		Read:        resourceElasticsearchDanglingIndexRead,
+		Delete:      resourceElasticsearchDanglingIndexDelete,
+		Schema: map[string]*schema.Schema{
+			"index_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the dangling index, see the `elasticsearch_dangling_indices` data source",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"import", "delete"}, false),
+				Description:  "Whether to `import` the dangling index into the cluster or to `delete` it",
+			},
+			"accept_data_loss": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Must be set to `true` to acknowledge that the index may be imported without its most recent data, or deleted",
+			},
+			"index_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the dangling index",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchDanglingIndexCreate(d *schema.ResourceData, meta interface{}) error {
+	uuid := d.Get("index_uuid").(string)
+	action := d.Get("action").(string)
+	if !d.Get("accept_data_loss").(bool) {
+		return fmt.Errorf("accept_data_loss must be set to true to %s the dangling index %s", action, uuid)
+	}
+
+	client, err := elastic7DanglingIndicesClient(meta)
+	if err != nil {
+		return err
+	}
+
+	indices, err := elastic7GetDanglingIndices(client)
+	if err != nil {
+		return err
+	}
+	var index *danglingIndex
+	for i := range indices {
+		if indices[i].IndexUUID == uuid {
+			index = &indices[i]
+		}
+	}
+	if index == nil {
+		return fmt.Errorf("no dangling index found with UUID %s", uuid)
+	}
+
+	path, err := uritemplates.Expand("/_dangling/{uuid}", map[string]string{
+		"uuid": uuid,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for dangling index: %+v", err)
+	}
+	method := http.MethodPost
+	if action == "delete" {
+		method = http.MethodDelete
+	}
+	params := url.Values{}
+	params.Set("accept_data_loss", "true")
+
+	_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: method,
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Performed %s of dangling index %s (%s)", action, index.IndexName, uuid)
+
+	d.SetId(uuid)
+	return d.Set("index_name", index.IndexName)
+}
+
+// The action is performed once, the index is no longer dangling afterwards
+func resourceElasticsearchDanglingIndexRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceElasticsearchDanglingIndexDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func elastic7DanglingIndicesClient(meta interface{}) (*elastic7.Client, error) {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return nil, errors.New("_dangling endpoint only available from ElasticSearch >= 7.9, got version < 7.0.0")
+	}
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return nil, err
+	}
+	if elasticVersion.LessThan(danglingIndicesMinimalVersion) {
+		return nil, fmt.Errorf("_dangling endpoint only available from ElasticSearch >= 7.9, got version %s", elasticVersion.String())
+	}
+	return client, nil
+}
+
+func elastic7GetDanglingIndices(client *elastic7.Client) ([]danglingIndex, error) {
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   "/_dangling",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		DanglingIndices []danglingIndex `json:"dangling_indices"`
+	}
+	if err := json.Unmarshal(res.Body, &resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling dangling indices body: %+v: %+v", err, res.Body)
+	}
+	return resp.DanglingIndices, nil
+}
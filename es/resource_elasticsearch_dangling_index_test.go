@@ -0,0 +1,78 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func TestAccElasticsearchDanglingIndex(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	var allowed bool
+	if client, ok := esClient.(*elastic7.Client); ok {
+		elasticVersion, err := elastic7GetVersion(client)
+		allowed = err == nil && !elasticVersion.LessThan(danglingIndicesMinimalVersion)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("_dangling endpoint only supported on ES >= 7.9")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// a healthy test cluster has no dangling indices
+				Config: testAccElasticsearchDataSourceDanglingIndices,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_dangling_indices.test", "dangling_indices.#", "0"),
+				),
+			},
+			{
+				Config:      testAccElasticsearchDanglingIndex,
+				ExpectError: regexp.MustCompile("no dangling index found with UUID"),
+			},
+			{
+				Config:      testAccElasticsearchDanglingIndexDataLoss,
+				ExpectError: regexp.MustCompile("accept_data_loss must be set to true"),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceDanglingIndices = `
+data "elasticsearch_dangling_indices" "test" {}
+`
+
+var testAccElasticsearchDanglingIndex = `
+resource "elasticsearch_dangling_index" "test" {
+  index_uuid       = "zmM4e0JtBkeUjiHD-MihPQ"
+  action           = "import"
+  accept_data_loss = true
+}
+`
+
+var testAccElasticsearchDanglingIndexDataLoss = `
+resource "elasticsearch_dangling_index" "test" {
+  index_uuid       = "zmM4e0JtBkeUjiHD-MihPQ"
+  action           = "delete"
+  accept_data_loss = false
+}
+`
@@ -0,0 +1,182 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+var forcemergeAsyncMinimalVersion, _ = version.NewVersion("7.7.0")
+
+type forcemergeResponse struct {
+	Task   string `json:"task"`
+	Shards struct {
+		Total      int `json:"total"`
+		Successful int `json:"successful"`
+		Failed     int `json:"failed"`
+	} `json:"_shards"`
+}
+
+func resourceElasticsearchForcemerge() *schema.Resource {
+	return &schema.Resource{
+		Description: "Force merges the shards of one or more indices, e.g. after large deletes or before a snapshot. The merge is performed once on create, changing any argument performs it again, destroying the resource only removes it from the state. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-forcemerge.html) for more details.",
+		Create:      resourceElasticsearchForcemergeCreate,
+		Read:        resourceElasticsearchForcemergeRead,
+		Delete:      resourceElasticsearchForcemergeDelete,
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index to force merge, a comma separated list or a pattern, e.g. `logs-*`",
+			},
+			"max_num_segments": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"only_expunge_deletes"},
+				Description:   "The number of segments to merge each shard to, defaults to checking whether a merge is needed",
+			},
+			"only_expunge_deletes": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				Default:       false,
+				ConflictsWith: []string{"max_num_segments"},
+				Description:   "Only expunge the segments containing document deletions",
+			},
+			"flush": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Flush the indices after the force merge",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Wait for the force merge to complete, otherwise it runs as a task whose ID is stored in `task_id` (ES >= 7.7)",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task of the force merge, when not waiting for its completion",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchForcemergeCreate(d *schema.ResourceData, meta interface{}) error {
+	index := d.Get("index").(string)
+	path, err := uritemplates.Expand("/{index}/_forcemerge", map[string]string{
+		"index": index,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for force merge: %+v", err)
+	}
+
+	params := url.Values{}
+	if maxNumSegments, ok := d.GetOk("max_num_segments"); ok {
+		params.Set("max_num_segments", strconv.Itoa(maxNumSegments.(int)))
+	}
+	params.Set("only_expunge_deletes", strconv.FormatBool(d.Get("only_expunge_deletes").(bool)))
+	params.Set("flush", strconv.FormatBool(d.Get("flush").(bool)))
+	waitForCompletion := d.Get("wait_for_completion").(bool)
+
+	var body json.RawMessage
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		if !waitForCompletion {
+			elasticVersion, err := elastic7GetVersion(client)
+			if err != nil {
+				return err
+			}
+			if elasticVersion.LessThan(forcemergeAsyncMinimalVersion) {
+				return fmt.Errorf("wait_for_completion can only be disabled from ElasticSearch >= 7.7, got version %s", elasticVersion.String())
+			}
+			params.Set("wait_for_completion", "false")
+		}
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "POST",
+			Path:   path,
+			Params: params,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		if !waitForCompletion {
+			return fmt.Errorf("wait_for_completion can only be disabled from ElasticSearch >= 7.7, got version < 7.0.0")
+		}
+		var res *elastic6.Response
+		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "POST",
+			Path:   path,
+			Params: params,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		if !waitForCompletion {
+			return fmt.Errorf("wait_for_completion can only be disabled from ElasticSearch >= 7.7, got version < 6.0.0")
+		}
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(context.TODO(), "POST", path, params, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var resp forcemergeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, body)
+	}
+	if resp.Shards.Failed > 0 {
+		return fmt.Errorf("force merge of %s failed on %d of %d shards", index, resp.Shards.Failed, resp.Shards.Total)
+	}
+	if resp.Task != "" {
+		log.Printf("[INFO] Started the force merge of %s as task %s", index, resp.Task)
+	} else {
+		log.Printf("[INFO] Force merged %d shards of %s", resp.Shards.Successful, index)
+	}
+
+	d.SetId(resource.UniqueId())
+	return d.Set("task_id", resp.Task)
+}
+
+// The merge is performed once, there is no state to read back
+func resourceElasticsearchForcemergeRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceElasticsearchForcemergeDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
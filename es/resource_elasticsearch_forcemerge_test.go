@@ -0,0 +1,49 @@
+package es
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchForcemerge(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchForcemerge,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticsearch_forcemerge.test", "id"),
+					resource.TestCheckResourceAttr("elasticsearch_forcemerge.test", "max_num_segments", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_forcemerge.test", "task_id", ""),
+				),
+			},
+			{
+				Config:      testAccElasticsearchForcemergeMissing,
+				ExpectError: regexp.MustCompile("terraform-test-missing"),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchForcemerge = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+}
+
+resource "elasticsearch_forcemerge" "test" {
+  index            = elasticsearch_index.test.name
+  max_num_segments = 1
+}
+`
+
+var testAccElasticsearchForcemergeMissing = `
+resource "elasticsearch_forcemerge" "test" {
+  index = "terraform-test-missing"
+}
+`
@@ -4,10 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
@@ -17,22 +29,164 @@ import (
 var (
 	staticSettingsKeys = []string{
 		"number_of_shards",
+		"number_of_routing_shards",
 		"codec",
 		"routing_partition_size",
 		"load_fixed_bitset_filters_eagerly",
+		"store.preload",
+		"store.type",
+		"soft_deletes.enabled",
+		"sort.field",
+		"sort.order",
+		"sort.mode",
+		"sort.missing",
 	}
 	dynamicsSettingsKeys = []string{
 		"number_of_replicas",
 		"auto_expand_replicas",
 		"refresh_interval",
-		//"max_result_window"
-		//"max_inner_result_window"
-		//"max_rescore_window"
-		//...
+		"mapping.total_fields.limit",
+		"mapping.depth.limit",
+		"mapping.nested_fields.limit",
+		"mapping.ignore_malformed",
+		"mapping.coerce",
+		"search.idle.after",
+		"max_docvalue_fields_search",
+		"highlight.max_analyzed_offset",
+		"max_terms_count",
+		"max_regex_length",
+		"max_slices_per_scroll",
+		"analyze.max_token_count",
+		"routing.allocation.enable",
+		"routing.rebalance.enable",
+		"write.wait_for_active_shards",
+		"query_string.lenient",
+		"blocks.read_only_allow_delete",
+		"lifecycle.indexing_complete",
+		"unassigned.node_left.delayed_timeout",
+		"gc_deletes",
+		"soft_deletes.retention_lease.period",
+		"soft_deletes.retention.operations",
+		"default_pipeline",
+		"final_pipeline",
+		"priority",
+		"translog.durability",
+		"translog.sync_interval",
+		"translog.flush_threshold_size",
+		"merge.policy.max_merged_segment",
+		"merge.policy.segments_per_tier",
+		"merge.scheduler.max_thread_count",
+		"search.slowlog.threshold.query.warn",
+		"search.slowlog.threshold.query.info",
+		"search.slowlog.threshold.query.debug",
+		"search.slowlog.threshold.query.trace",
+		"search.slowlog.threshold.fetch.warn",
+		"search.slowlog.threshold.fetch.info",
+		"search.slowlog.threshold.fetch.debug",
+		"search.slowlog.threshold.fetch.trace",
+		"indexing.slowlog.threshold.index.warn",
+		"indexing.slowlog.threshold.index.info",
+		"indexing.slowlog.threshold.index.debug",
+		"indexing.slowlog.threshold.index.trace",
+		"indexing.slowlog.source",
 	}
 	settingsKeys = append(staticSettingsKeys, dynamicsSettingsKeys...)
+	// settings managed under a shorter attribute name
+	settingsSchemaNames = map[string]string{
+		"unassigned.node_left.delayed_timeout": "delayed_timeout",
+		"lifecycle.indexing_complete":          "indexing_complete",
+	}
+)
+
+// searchLimitsSettingsKeys maps the attributes of the `search_limits` block to
+// their settings keys, some of which are also available as flat attributes
+var searchLimitsSettingsKeys = map[string]string{
+	"max_result_window":             "max_result_window",
+	"max_inner_result_window":       "max_inner_result_window",
+	"max_rescore_window":            "max_rescore_window",
+	"max_docvalue_fields_search":    "max_docvalue_fields_search",
+	"max_script_fields":             "max_script_fields",
+	"max_terms_count":               "max_terms_count",
+	"max_regex_length":              "max_regex_length",
+	"max_slices_per_scroll":         "max_slices_per_scroll",
+	"highlight_max_analyzed_offset": "highlight.max_analyzed_offset",
+}
+
+// routingAllocationPrefix is the settings namespace for the shard allocation
+// filters managed through the `routing_allocation` attribute.
+const routingAllocationPrefix = "routing.allocation."
+
+var routingAllocationFilters = []string{"require.", "include.", "exclude."}
+
+// internalIndexSettings are set by the cluster and can't be configured
+var internalIndexSettings = []string{"uuid", "creation_date", "provided_name", "version.created", "version.upgraded"}
+
+// staticIndexSetting bounds the versions in which a setting is static, from
+// `since` and before `until`, unbounded when empty
+type staticIndexSetting struct {
+	since string
+	until string
+}
+
+// staticIndexSettings classifies the keys, or the prefixes ending with a dot,
+// of the `settings` JSON which can only be set on creation or on a closed
+// index, any other key is dynamic. Add to them as static settings are released
+var staticIndexSettings = map[string]staticIndexSetting{
+	"analysis.":              {},
+	"similarity.":            {},
+	"shard.check_on_startup": {},
+	"queries.cache.enabled":  {},
+	"format":                 {},
+	"mapping.single_type":    {since: "5.5.0", until: "7.0.0"},
+	"mode":                   {since: "8.0.0"},
+	"routing_path":           {since: "8.1.0"},
+}
+
+// timeValueRegexp matches an ES time value, e.g. `30s`, `0` or `-1` to disable
+var timeValueRegexp = regexp.MustCompile(`^(-1|0|\d+(d|h|m|s|ms|micros|nanos))$`)
+
+// byteSizeValueRegexp matches an ES byte size value, e.g. `512mb`
+var byteSizeValueRegexp = regexp.MustCompile(`^\d+(\.\d+)?(b|kb|mb|gb|tb|pb)$`)
+
+// indexCodecs are the accepted values of `index.codec`, the zstd ones only
+// from zstdCodecMinimalVersion
+var (
+	indexCodecs                = []string{"default", "best_compression", "zstd", "best_compression_zstd"}
+	zstdCodecMinimalVersion, _ = version.NewVersion("8.0.0")
+)
+
+// is_write_index of aliases is supported from ES 6.4
+var aliasIsWriteIndexMinimalVersion, _ = version.NewVersion("6.4.0")
+
+// include_type_name is accepted from ES 6.7 until ES 8, where only typeless
+// mappings remain
+var (
+	includeTypeNameMinimalVersion, _  = version.NewVersion("6.7.0")
+	typelessMappingsMinimalVersion, _ = version.NewVersion("7.0.0")
+	includeTypeNameMaximalVersion, _  = version.NewVersion("8.0.0")
 )
 
+// mappingFieldTypeMinimalVersions are the versions from which field types
+// newer than ES 5 are supported, add to them as new types are released
+var mappingFieldTypeMinimalVersions = map[string]string{
+	"join":                    "6.0.0",
+	"alias":                   "6.4.0",
+	"date_nanos":              "7.0.0",
+	"dense_vector":            "7.0.0",
+	"rank_feature":            "7.0.0",
+	"rank_features":           "7.0.0",
+	"search_as_you_type":      "7.2.0",
+	"flattened":               "7.3.0",
+	"shape":                   "7.4.0",
+	"histogram":               "7.6.0",
+	"constant_keyword":        "7.7.0",
+	"wildcard":                "7.9.0",
+	"unsigned_long":           "7.10.0",
+	"version":                 "7.10.0",
+	"aggregate_metric_double": "7.11.0",
+	"match_only_text":         "7.14.0",
+}
+
 var (
 	configSchema = map[string]*schema.Schema{
 		"name": {
@@ -47,17 +201,48 @@ var (
 			Default:     false,
 			Optional:    true,
 		},
+		"adopt_existing": {
+			Type:        schema.TypeBool,
+			Description: "A boolean that indicates that an index which already exists should be adopted on create, as if it was imported, instead of failing.",
+			Default:     false,
+			Optional:    true,
+		},
+		"wait_for_status": {
+			Type:         schema.TypeString,
+			Description:  "The health status, `green` or `yellow`, to wait for after creating the index, bounded by the create timeout, or `none` not to wait. Defaults to `yellow`.",
+			Default:      "yellow",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "none"}, false),
+		},
+		"wait_for_rollover_alias": {
+			Type:        schema.TypeBool,
+			Description: "A boolean that indicates that the create should wait, bounded by the create timeout, until the ILM or ISM policy of a matching index template is attached to the index and `rollover_alias` can be read, i.e. until `index.lifecycle.rollover_alias` or `opendistro.index_state_management.rollover_alias` is set.",
+			Default:     false,
+			Optional:    true,
+		},
+		"wait_for_deletion": {
+			Type:        schema.TypeBool,
+			Description: "A boolean that indicates that a delete should wait until the index no longer exists, bounded by the delete timeout.",
+			Default:     false,
+			Optional:    true,
+		},
 		// Static settings that can only be set on creation
 		"number_of_shards": {
 			Type:        schema.TypeString,
-			Description: "Number of shards for the index. This can be set only on creation.",
+			Description: "Number of shards for the index. This can be set only on creation, and can't be changed while `rollover_alias` is set.",
 			ForceNew:    true,
 			Default:     "1",
 			Optional:    true,
 		},
+		"number_of_routing_shards": {
+			Type:        schema.TypeInt,
+			Description: "Value used with `number_of_shards` to route documents to a primary shard, which determines the number of shards the index can be split into. This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+		},
 		"routing_partition_size": {
 			Type:        schema.TypeInt,
-			Description: "The number of shards a custom routing value can go to. This can be set only on creation.",
+			Description: "The number of shards a custom routing value can go to, which must be less than `number_of_routing_shards`, or `number_of_shards` when it is not set. This can be set only on creation.",
 			ForceNew:    true,
 			Optional:    true,
 		},
@@ -67,17 +252,76 @@ var (
 			ForceNew:    true,
 			Optional:    true,
 		},
-		"codec": {
-			Type:        schema.TypeString,
-			Description: "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. This can be set only on creation.",
+		"store_preload": {
+			Type:        schema.TypeList,
+			Description: "File extensions, e.g. `nvd` and `dvd`, of the index files to pre-populate the file system cache with when the index is opened, or `*` for all files. This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"store_type": {
+			Type:         schema.TypeString,
+			Description:  "The type of the file system storage of the index, `fs` for the default of the platform, `simplefs`, `niofs`, `mmapfs` or `hybridfs`. This can be set only on creation.",
+			ForceNew:     true,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"fs", "simplefs", "niofs", "mmapfs", "hybridfs"}, false),
+		},
+		"sort_field": {
+			Type:        schema.TypeList,
+			Description: "The fields to sort the segments of the index by, which must be mapped as `boolean`, numeric, `date` or `keyword` fields with doc values (ES >= 6.0). This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"sort_order": {
+			Type:        schema.TypeList,
+			Description: "The sort order of each of the `sort_field`, `asc` or `desc`. This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+			},
+		},
+		"sort_mode": {
+			Type:        schema.TypeList,
+			Description: "The value of each of the multi-valued `sort_field` to sort by, `min` or `max`. This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"min", "max"}, false),
+			},
+		},
+		"sort_missing": {
+			Type:        schema.TypeList,
+			Description: "Where documents missing each of the `sort_field` are sorted, `_first` or `_last`. This can be set only on creation.",
+			ForceNew:    true,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"_first", "_last"}, false),
+			},
+		},
+		"soft_deletes_enabled": {
+			Type:        schema.TypeBool,
+			Description: "Whether soft deletes are enabled on the index, which cross-cluster replication requires of leader indices (ES >= 6.5). This can be set only on creation, unlike the `soft_deletes_retention_*` settings which are updated in place.",
 			ForceNew:    true,
 			Optional:    true,
 		},
+		"codec": {
+			Type:         schema.TypeString,
+			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio, or to `zstd` and `best_compression_zstd` on ES >= 8. This can be set only on creation.",
+			ForceNew:     true,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(indexCodecs, false),
+		},
 		// Dynamic settings that can be changed at runtime
 		"number_of_replicas": {
-			Type:        schema.TypeString,
-			Description: "Number of shard replicas",
-			Optional:    true,
+			Type:             schema.TypeString,
+			Description:      "Number of shard replicas. Changes are ignored while `auto_expand_replicas` is set.",
+			Optional:         true,
+			DiffSuppressFunc: diffSuppressIndexReplicas,
 		},
 		"auto_expand_replicas": {
 			Type:        schema.TypeString, // 0-5 OR 0-all
@@ -89,22 +333,400 @@ var (
 			Description: "How often to perform a refresh operation, which makes recent changes to the index visible to search. Can be set to `-1` to disable refresh.",
 			Optional:    true,
 		},
-		// Other attributes
-		"mappings": {
+		"mapping_total_fields_limit": {
+			Type:        schema.TypeInt,
+			Description: "The maximum number of fields in an index. Field and object mappings, as well as field aliases count towards this limit.",
+			Optional:    true,
+		},
+		"mapping_depth_limit": {
+			Type:        schema.TypeInt,
+			Description: "The maximum depth for a field, which is measured as the number of inner objects.",
+			Optional:    true,
+		},
+		"mapping_nested_fields_limit": {
+			Type:        schema.TypeInt,
+			Description: "The maximum number of distinct `nested` mappings in an index.",
+			Optional:    true,
+		},
+		"mapping_ignore_malformed": {
+			Type:        schema.TypeBool,
+			Description: "Whether to ignore values of the wrong data type instead of rejecting the whole document, unless overridden on the field mapping.",
+			Optional:    true,
+		},
+		"mapping_coerce": {
+			Type:        schema.TypeBool,
+			Description: "Whether to coerce values to the data type of their field, e.g. strings to numbers, unless overridden on the field mapping.",
+			Optional:    true,
+		},
+		"search_idle_after": {
 			Type:         schema.TypeString,
-			Description:  "A JSON string defining how documents in the index, and the fields they contain, are stored and indexed. To avoid the complexities of field mapping updates, updates of this field are not allowed via this provider. See the upstream [Elasticsearch docs](https://www.elastic.co/guide/en/elasticsearch/reference/6.8/indices-put-mapping.html#updating-field-mappings) for more details.",
+			Description:  "How long a shard can not receive a search or get request until it's considered search idle, e.g. `30s`.",
 			Optional:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.StringIsJSON,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `30s`, or `-1`"),
+		},
+		"max_docvalue_fields_search": {
+			Type:        schema.TypeInt,
+			Description: "The maximum number of `docvalue_fields` that are allowed in a query.",
+			Optional:    true,
+		},
+		"highlight_max_analyzed_offset": {
+			Type:        schema.TypeInt,
+			Description: "The maximum number of characters that will be analyzed for a highlight request.",
+			Optional:    true,
+		},
+		"max_terms_count": {
+			Type:        schema.TypeInt,
+			Description: "The maximum number of terms that can be used in a `terms` query.",
+			Optional:    true,
+		},
+		"max_regex_length": {
+			Type:        schema.TypeInt,
+			Description: "The maximum length of a regex that can be used in a `regexp` query.",
+			Optional:    true,
+		},
+		"max_slices_per_scroll": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of slices of a sliced scroll, e.g. of a sliced reindex or export.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"analyze_max_token_count": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of tokens that can be produced using the `_analyze` API.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"search_limits": {
+			Type:          schema.TypeList,
+			Description:   "Groups the limits of search requests on the index, as an alternative to the flat attributes, e.g. `max_terms_count`, which it conflicts with.",
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"max_docvalue_fields_search", "highlight_max_analyzed_offset", "max_terms_count", "max_regex_length", "max_slices_per_scroll"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"max_result_window": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum `from + size` of searches, which bounds the depth of paging.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_inner_result_window": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum `from + size` of inner hits and top hits aggregations.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_rescore_window": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum `window_size` of `rescore` requests.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_docvalue_fields_search": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum number of `docvalue_fields` that are allowed in a query.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_script_fields": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum number of `script_fields` that are allowed in a query.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_terms_count": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum number of terms that can be used in a `terms` query.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_regex_length": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum length of a regex that can be used in a `regexp` query.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"max_slices_per_scroll": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum number of slices of a sliced scroll.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"highlight_max_analyzed_offset": {
+						Type:         schema.TypeInt,
+						Description:  "The maximum number of characters that will be analyzed for a highlight request.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+		"routing_allocation_enable": {
+			Type:         schema.TypeString,
+			Description:  "Controls shard allocation for the index, one of `all`, `primaries`, `new_primaries` or `none`, e.g. to disable it during a rolling restart.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"all", "primaries", "new_primaries", "none"}, false),
+		},
+		"routing_rebalance_enable": {
+			Type:         schema.TypeString,
+			Description:  "Enables shard rebalancing for the index, one of `all`, `primaries`, `replicas` or `none`.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"all", "primaries", "replicas", "none"}, false),
+		},
+		"write_wait_for_active_shards": {
+			Type:         schema.TypeString,
+			Description:  "The number of shard copies that must be active before write operations proceed, `all` or a number up to the number of copies, e.g. `1` for only the primary shard.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(all|\d+)$`), "must be `all` or a number"),
+		},
+		"indexing_complete": {
+			Type:        schema.TypeBool,
+			Description: "Whether the index is done with ILM rollovers, e.g. to finalize an index removed from its rollover series, which otherwise fails the rollover step of its lifecycle policy (ES >= 6.6). Serialized as `index.lifecycle.indexing_complete`.",
+			Optional:    true,
+		},
+		"query_string_lenient": {
+			Type:        schema.TypeBool,
+			Description: "Whether format-based failures, e.g. providing text to a numeric field, are ignored by `query_string` queries on the index which don't set `lenient`.",
+			Optional:    true,
+		},
+		"blocks_read_only_allow_delete": {
+			Type:        schema.TypeBool,
+			Description: "Whether the index is read-only, except for deleting it or documents, as set by ES when the flood stage disk watermark is exceeded. Setting it to `false`, or removing it, releases the block once disk space was freed.",
+			Optional:    true,
+		},
+		"delayed_timeout": {
+			Type:         schema.TypeString,
+			Description:  "How long to delay the allocation of replica shards which become unassigned because a node has left, e.g. `5m`. Serialized as `index.unassigned.node_left.delayed_timeout`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `5m`"),
+		},
+		"gc_deletes": {
+			Type:         schema.TypeString,
+			Description:  "How long a deleted document's version number remains available for further versioned operations, e.g. `60s`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `60s`"),
+		},
+		"soft_deletes_retention_lease_period": {
+			Type:         schema.TypeString,
+			Description:  "How long the history of operations is retained for a shard history retention lease, e.g. of a cross-cluster replication follower, before the lease expires, e.g. `12h` (ES >= 6.7).",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `12h`"),
+		},
+		"soft_deletes_retention_operations": {
+			Type:         schema.TypeInt,
+			Description:  "The number of soft deleted operations retained in addition to those required by retention leases, e.g. for a peer recovery from history (ES >= 6.5).",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"default_pipeline": {
+			Type:        schema.TypeString,
+			Description: "The ingest pipeline run on documents indexed without a `pipeline` parameter, or `_none` for no pipeline (ES >= 6.5). Removing it detaches the pipeline.",
+			Optional:    true,
+		},
+		"final_pipeline": {
+			Type:        schema.TypeString,
+			Description: "The ingest pipeline run on every indexed document after any request or default pipeline, or `_none` for no pipeline (ES >= 7.5). Removing it detaches the pipeline.",
+			Optional:    true,
+		},
+		"priority": {
+			Type:        schema.TypeInt,
+			Description: "The priority of the index when recovering unassigned shards, indices with a higher priority are recovered first.",
+			Optional:    true,
+		},
+		"translog_durability": {
+			Type:         schema.TypeString,
+			Description:  "Whether to fsync and commit the translog after every request, `request`, or in the background every `translog_sync_interval`, `async`.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"request", "async"}, false),
+		},
+		"translog_sync_interval": {
+			Type:         schema.TypeString,
+			Description:  "How often the translog is fsynced to disk and committed, regardless of write operations, e.g. `5s`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `5s`"),
+		},
+		"translog_flush_threshold_size": {
+			Type:         schema.TypeString,
+			Description:  "The size of the translog which triggers a flush, which prevents recoveries from replaying too many operations, e.g. `512mb`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(byteSizeValueRegexp, "must be a byte size value, e.g. `512mb`"),
+		},
+		"merge_policy_max_merged_segment": {
+			Type:         schema.TypeString,
+			Description:  "The maximum size of a segment produced by merges, e.g. `5gb`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(byteSizeValueRegexp, "must be a byte size value, e.g. `5gb`"),
+		},
+		"merge_policy_segments_per_tier": {
+			Type:         schema.TypeInt,
+			Description:  "The number of segments allowed per tier before merging, lower values mean more merging and fewer segments.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(2),
+		},
+		"merge_scheduler_max_thread_count": {
+			Type:         schema.TypeInt,
+			Description:  "The maximum number of threads on a single shard that may be merging at once, e.g. `1` for spinning disks.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"search_slowlog_threshold_query_warn": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the query phase of a shard above which it is logged to the search slow log at `warn` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_query_info": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the query phase of a shard above which it is logged to the search slow log at `info` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_query_debug": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the query phase of a shard above which it is logged to the search slow log at `debug` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_query_trace": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the query phase of a shard above which it is logged to the search slow log at `trace` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_fetch_warn": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the fetch phase of a shard above which it is logged to the search slow log at `warn` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_fetch_info": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the fetch phase of a shard above which it is logged to the search slow log at `info` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_fetch_debug": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the fetch phase of a shard above which it is logged to the search slow log at `debug` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"search_slowlog_threshold_fetch_trace": {
+			Type:         schema.TypeString,
+			Description:  "The duration of the fetch phase of a shard above which it is logged to the search slow log at `trace` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"indexing_slowlog_threshold_index_warn": {
+			Type:         schema.TypeString,
+			Description:  "The duration of an indexing operation on a shard above which it is logged to the indexing slow log at `warn` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"indexing_slowlog_threshold_index_info": {
+			Type:         schema.TypeString,
+			Description:  "The duration of an indexing operation on a shard above which it is logged to the indexing slow log at `info` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"indexing_slowlog_threshold_index_debug": {
+			Type:         schema.TypeString,
+			Description:  "The duration of an indexing operation on a shard above which it is logged to the indexing slow log at `debug` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"indexing_slowlog_threshold_index_trace": {
+			Type:         schema.TypeString,
+			Description:  "The duration of an indexing operation on a shard above which it is logged to the indexing slow log at `trace` level, e.g. `10s`, or `-1` to disable.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `10s`, or `-1`"),
+		},
+		"indexing_slowlog_source": {
+			Type:         schema.TypeString,
+			Description:  "How many characters of the `_source` of a document are logged to the indexing slow log, e.g. `1000`, `true` for the whole source or `false` for none.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(true|false|\d+)$`), "must be a number of characters, `true` or `false`"),
+		},
+		"routing_allocation": {
+			Type:         schema.TypeMap,
+			Description:  "Shard allocation filters, keyed by filter type and node attribute, e.g. `require.box_type = \"hot\"`. Serialized under `index.routing.allocation.*`.",
+			Optional:     true,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			ValidateFunc: validateRoutingAllocation,
+		},
+		// Other attributes
+		"ignore_settings": {
+			Type:        schema.TypeSet,
+			Description: "Settings keys, e.g. `index.refresh_interval` or `index.routing.allocation.require.box_type`, that are managed outside of this resource. They are neither read back nor updated.",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"settings": {
+			Type:             schema.TypeString,
+			Description:      "A JSON object of index settings without a dedicated attribute, e.g. `{\"index.requests.cache.enable\": false}`, in the flat or nested form, with or without the `index.` prefix. They are merged into the settings of the index, only the keys present are read back, and changing a static setting, e.g. under `analysis`, recreates the index. Settings with a dedicated attribute, e.g. `number_of_replicas`, must be set with it. When a static setting or another attribute which recreates the index, e.g. `number_of_shards`, changes along with dynamic settings, the dynamic changes are applied to the new index rather than to the existing one, which is only logged as a warning with `TF_LOG=WARN`.",
+			Optional:         true,
+			ValidateFunc:     validateIndexSettingsJSON,
+			DiffSuppressFunc: diffSuppressIndexSettings,
+		},
+		"settings_file": {
+			Type:        schema.TypeString,
+			Description: "Path of a JSON file of `settings`, as an alternative to it for large settings, e.g. analysis definitions. The file is read when the index is created, changes to its contents are not detected, changing the path recreates the index.",
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"mappings": {
+			Type:             schema.TypeString,
+			Description:      "A JSON string defining how documents in the index, and the fields they contain, are stored and indexed. To avoid the complexities of field mapping updates, updates of this field are not allowed via this provider. See the upstream [Elasticsearch docs](https://www.elastic.co/guide/en/elasticsearch/reference/6.8/indices-put-mapping.html#updating-field-mappings) for more details. Both the typed layout of ES < 7, e.g. `{\"_doc\": {\"properties\": ...}}`, and the typeless layout of ES >= 7 are accepted, and converted to the layout of the cluster. Field types which the version of the cluster doesn't support, e.g. `wildcard` before ES 7.9, are reported at plan time.",
+			Optional:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: diffSuppressIndexMappings,
+		},
+		"mappings_file": {
+			Type:        schema.TypeString,
+			Description: "Path of a JSON file of `mappings`, as an alternative to it for large mappings. The file is read when the index is created, changes to its contents are not detected, changing the path recreates the index.",
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"validate_mapping": {
+			Type:        schema.TypeBool,
+			Description: "Whether to validate `mappings` at plan time, by checking that they define `properties` and creating a throwaway index from them, along with the `mapping_*` and `sort_*` settings, which is deleted right away. The index is named `terraform-provider-elasticsearch-validate-mapping-*`, on which the credentials of the provider need the `create_index` and `delete_index` privileges, even to plan.",
+			Optional:    true,
+		},
+		"include_type_name": {
+			Type:        schema.TypeBool,
+			Description: "Whether the mappings are sent to the cluster in the typed layout, i.e. with a mapping type, which is passed as the `include_type_name` parameter when creating the index. Defaults to `true` on ES < 7 and `false` on ES >= 7. Only supported from ES 6.7 and before ES 8, e.g. set it to `false` on ES 6.8 to create typeless indices ahead of an upgrade to ES 7, or to `true` on ES 7 to create indices from the typed mappings of ES 6.",
+			Optional:    true,
 		},
 		"aliases": {
 			Type:        schema.TypeString,
-			Description: "A JSON string describing a set of aliases. The index aliases API allows aliasing an index with a name, with all APIs automatically converting the alias name to the actual index name. An alias can also be mapped to more than one index, and when specifying it, the alias will automatically expand to the aliased indices.",
+			Description: "A JSON string describing a set of aliases. The index aliases API allows aliasing an index with a name, with all APIs automatically converting the alias name to the actual index name. An alias can also be mapped to more than one index, and when specifying it, the alias will automatically expand to the aliased indices. All the aliases are read back from the cluster on import, otherwise only the configured ones are, ignoring the ones removed from the index, e.g. by `elasticsearch_alias_swap`, and `is_write_index` and `is_hidden` when they aren't configured, so that changes made outside of Terraform don't recreate the index. Each alias can only be defined once, and can only be marked as the write index with `is_write_index` when it doesn't already have another write index.",
 			Optional:    true,
 			// In order to not handle the separate endpoint of alias updates, updates
 			// are not allowed via this provider currently.
-			ForceNew:     true,
-			ValidateFunc: validation.StringIsJSON,
+			ForceNew:         true,
+			ValidateFunc:     validateIndexAliases,
+			DiffSuppressFunc: diffSuppressIndexAliases,
+		},
+		"aliases_file": {
+			Type:        schema.TypeString,
+			Description: "Path of a JSON file of `aliases`, as an alternative to it. The file is read when the index is created, changes to its contents are not detected, changing the path recreates the index.",
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"documents": {
+			Type:         schema.TypeString,
+			Description:  "A JSON array of documents bulk indexed into the index after its creation, e.g. to seed small reference data. A document's `_id` key, if any, is used as its ID. The documents are not read back, only a hash of them is kept in the state, and they are indexed again when changed. Destroying an index with documents requires `force_destroy`.",
+			Optional:     true,
+			ValidateFunc: validateIndexDocuments,
+			StateFunc:    hashSum,
+		},
+		"documents_refresh": {
+			Type:         schema.TypeString,
+			Description:  "Whether to refresh the index after indexing the `documents`, `true`, `false` or `wait_for`.",
+			Optional:     true,
+			Default:      "false",
+			ValidateFunc: validation.StringInSlice([]string{"true", "false", "wait_for"}, false),
 		},
 		// Computed attributes
 		"rollover_alias": {
@@ -112,165 +734,1225 @@ var (
 			Optional: true,
 			Computed: true,
 		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Description: "The UUID of the index, e.g. to correlate it with snapshots or dangling indices.",
+			Computed:    true,
+		},
+		"creation_date": {
+			Type:        schema.TypeString,
+			Description: "When the index was created, as an RFC 3339 timestamp, e.g. `2021-03-01T12:00:00Z`.",
+			Computed:    true,
+		},
+		"version_created": {
+			Type:        schema.TypeString,
+			Description: "The internal ID of the ES version which created the index, e.g. `7100099` for ES 7.10.0.",
+			Computed:    true,
+		},
 	}
 )
 
 func resourceElasticsearchIndex() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an Elasticsearch index resource.",
-		Create:      resourceElasticsearchIndexCreate,
-		Read:        resourceElasticsearchIndexRead,
-		Update:      resourceElasticsearchIndexUpdate,
-		Delete:      resourceElasticsearchIndexDelete,
-		Schema:      configSchema,
+		Description:   "Provides an Elasticsearch index resource.",
+		Create:        resourceElasticsearchIndexCreate,
+		Read:          resourceElasticsearchIndexRead,
+		Update:        resourceElasticsearchIndexUpdate,
+		Delete:        resourceElasticsearchIndexDelete,
+		Schema:        configSchema,
+		CustomizeDiff: resourceElasticsearchIndexCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceElasticsearchIndexImport,
 		},
 	}
 }
 
-func resourceElasticsearchIndexCreate(d *schema.ResourceData, meta interface{}) error {
-	var (
-		name     = d.Get("name").(string)
-		settings = settingsFromIndexResourceData(d)
-		body     = make(map[string]interface{})
-		ctx      = context.Background()
-		err      error
-	)
-	if len(settings) > 0 {
-		body["settings"] = settings
+// resourceElasticsearchIndexImport imports an index by name, or the write
+// index of an alias, e.g. the rollover alias of an index managed by ILM
+func resourceElasticsearchIndexImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if index := getWriteIndexByAlias(id, d, meta); index != id {
+		log.Printf("[INFO] Importing the write index %s of the alias %s", index, id)
+		d.SetId(index)
+		if err := d.Set("rollover_alias", id); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceElasticsearchIndexCustomizeDiff warns when dynamic settings change
+// alongside an attribute that forces the index to be recreated, as they are
+// then only applied to the new index, and forbids changing the shards of an
+// index in a rollover series or using a codec the cluster doesn't support
+func resourceElasticsearchIndexCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if codec, ok := d.GetOk("codec"); ok && d.HasChange("codec") {
+		if err := checkIndexCodecSupported(codec.(string), meta); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range indexJSONFileAttributes {
+		_, inline := d.GetOk(name)
+		_, file := d.GetOk(name + "_file")
+		if inline && file {
+			return fmt.Errorf("only one of `%s` and `%s_file` can be set", name, name)
+		}
+	}
+
+	mappingsChanged := d.HasChange("mappings") || d.HasChange("mappings_file")
+	validateMapping := d.Get("validate_mapping").(bool) && (mappingsChanged || d.HasChange("validate_mapping"))
+	if mappingsChanged || validateMapping {
+		mappings, ok, err := indexJSON(d, "mappings")
+		if err != nil {
+			return err
+		}
+		if ok && mappingsChanged {
+			if err := checkIndexMappingsSupported(mappings, meta); err != nil {
+				return err
+			}
+		}
+		if ok && validateMapping {
+			if err := validateIndexMappings(d, mappings, meta); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the files are read when they change, the inline attributes are
+	// validated by their schema
+	if d.HasChange("aliases_file") {
+		if aliases, ok, err := indexJSONFromFile(d, "aliases"); err != nil {
+			return err
+		} else if ok {
+			if _, errs := validateIndexAliases(aliases, "aliases_file"); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+	}
+	if d.HasChange("settings_file") {
+		if settings, ok, err := indexJSONFromFile(d, "settings"); err != nil {
+			return err
+		} else if ok {
+			if _, errs := validateIndexSettingsJSON(settings, "settings_file"); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+	}
+
+	if d.HasChange("routing_partition_size") || d.HasChange("number_of_shards") || d.HasChange("number_of_routing_shards") {
+		if err := checkIndexRoutingPartitionSize(d); err != nil {
+			return err
+		}
+	}
+
+	if includeTypeName, ok := d.GetOkExists("include_type_name"); ok && d.HasChange("include_type_name") {
+		// getClient detects the version of the cluster, unless configured
+		conf := meta.(*ProviderConf)
+		if _, err := getClient(conf); err != nil {
+			return err
+		}
+		if _, _, err := indexIncludeTypeName(conf.esVersion, includeTypeName, true); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("settings") {
+		// getClient detects the version of the cluster, unless configured
+		conf := meta.(*ProviderConf)
+		if _, err := getClient(conf); err != nil {
+			return err
+		}
+		elasticVersion, err := version.NewVersion(conf.esVersion)
+		if err != nil {
+			return err
+		}
+		o, n := d.GetChange("settings")
+		if key, ok := changedStaticIndexSetting(o.(string), n.(string), elasticVersion); ok {
+			log.Printf("[INFO] index %q will be recreated because the static setting %q changed", d.Id(), key)
+			if err := d.ForceNew("settings"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if alias, ok := d.GetOk("rollover_alias"); ok && d.HasChange("number_of_shards") {
+		return fmt.Errorf("number_of_shards can't be changed on index %q, which is part of the rollover series of %q: recreating it would destroy the current write index, change the shards in the index template or lifecycle policy instead", d.Id(), alias)
+	}
+
+	var forceNew, dynamic []string
+	for name, s := range configSchema {
+		if s.ForceNew && d.HasChange(name) {
+			forceNew = append(forceNew, name)
+		}
+	}
+	for _, key := range dynamicsSettingsKeys {
+		if name := settingSchemaName(key); d.HasChange(name) {
+			dynamic = append(dynamic, name)
+		}
+	}
+	if d.HasChange("routing_allocation") {
+		dynamic = append(dynamic, "routing_allocation")
+	}
+	if d.HasChange("search_limits") {
+		dynamic = append(dynamic, "search_limits")
+	}
+	if d.HasChange("settings") {
+		dynamic = append(dynamic, "settings")
+	}
+
+	if len(forceNew) > 0 && len(dynamic) > 0 {
+		sort.Strings(forceNew)
+		sort.Strings(dynamic)
+		log.Printf("[WARN] index %q will be recreated because of changes to %s, changes to %s will be applied to the new index instead of updating the existing one", d.Id(), strings.Join(forceNew, ", "), strings.Join(dynamic, ", "))
+	}
+	return nil
+}
+
+// indexJSONFileAttributes are the JSON attributes which can be read from the
+// file of their `_file` counterpart instead
+var indexJSONFileAttributes = []string{"mappings", "aliases", "settings"}
+
+// resourceGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff
+type resourceGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// indexJSON returns the JSON of an inline attribute, e.g. `mappings`, or else
+// of the file of its `_file` counterpart, and whether either is set
+func indexJSON(d resourceGetter, name string) (string, bool, error) {
+	if value, ok := d.GetOk(name); ok {
+		return value.(string), true, nil
+	}
+	return indexJSONFromFile(d, name)
+}
+
+// indexJSONFromFile returns the JSON of the file of the `_file` counterpart of
+// an attribute, and whether it is set
+func indexJSONFromFile(d resourceGetter, name string) (string, bool, error) {
+	path, ok := d.GetOk(name + "_file")
+	if !ok {
+		return "", false, nil
+	}
+	bytes, err := ioutil.ReadFile(path.(string))
+	if err != nil {
+		return "", false, fmt.Errorf("fail to read %s_file: %v", name, err)
+	}
+	if !json.Valid(bytes) {
+		return "", false, fmt.Errorf("%s_file: %s must contain JSON", name, path)
+	}
+	return string(bytes), true, nil
+}
+
+// checkIndexRoutingPartitionSize checks that routing_partition_size, when
+// partitioning, is less than the number of routing shards, which defaults to
+// number_of_shards, as ES only rejects it on creation
+func checkIndexRoutingPartitionSize(d *schema.ResourceDiff) error {
+	partitionSize, ok := d.GetOk("routing_partition_size")
+	if !ok || partitionSize.(int) == 1 {
+		return nil
+	}
+
+	name := "number_of_shards"
+	shards, err := strconv.Atoi(d.Get("number_of_shards").(string))
+	if routingShards, ok := d.GetOk("number_of_routing_shards"); ok {
+		name = "number_of_routing_shards"
+		shards, err = routingShards.(int), nil
+	}
+	// unknown until apply
+	if err != nil {
+		return nil
+	}
+
+	if partitionSize.(int) >= shards {
+		return fmt.Errorf("routing_partition_size (%d) must be less than %s (%d)", partitionSize.(int), name, shards)
+	}
+	return nil
+}
+
+func checkIndexCodecSupported(codec string, meta interface{}) error {
+	if !strings.Contains(codec, "zstd") {
+		return nil
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("codec %q is only supported from ElasticSearch >= 8.0, got version < 7.0.0", codec)
+	}
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(zstdCodecMinimalVersion) {
+		return fmt.Errorf("codec %q is only supported from ElasticSearch >= 8.0, got version %s", codec, elasticVersion.String())
+	}
+	return nil
+}
+
+// indexIncludeTypeName returns whether the mappings of an index are in the
+// typed layout, which defaults to that of the version of the cluster, and
+// whether include_type_name needs to be passed along to get that layout
+func indexIncludeTypeName(esVersion string, includeTypeName interface{}, set bool) (bool, bool, error) {
+	elasticVersion, err := version.NewVersion(esVersion)
+	if err != nil {
+		return false, false, err
+	}
+
+	typed := elasticVersion.LessThan(typelessMappingsMinimalVersion)
+	if !set || includeTypeName.(bool) == typed {
+		return typed, false, nil
+	}
+	if elasticVersion.LessThan(includeTypeNameMinimalVersion) || !elasticVersion.LessThan(includeTypeNameMaximalVersion) {
+		return typed, false, fmt.Errorf("include_type_name is only supported from ElasticSearch >= %s and < %s, got version %s", includeTypeNameMinimalVersion.String(), includeTypeNameMaximalVersion.String(), elasticVersion.String())
+	}
+	return includeTypeName.(bool), true, nil
+}
+
+// checkIndexMappingsSupported errors when the mappings use field types which
+// the version of the cluster doesn't support, rather than failing on apply
+func checkIndexMappingsSupported(mappingsJSON string, meta interface{}) error {
+	var mappings map[string]interface{}
+	if err := json.Unmarshal([]byte(mappingsJSON), &mappings); err != nil {
+		return fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	var types []string
+	for _, fieldType := range mappingFieldTypes(normalizeIndexMappings(mappings)) {
+		if _, ok := mappingFieldTypeMinimalVersions[fieldType]; ok {
+			types = append(types, fieldType)
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+
+	// getClient detects the version of the cluster, unless configured
+	conf := meta.(*ProviderConf)
+	if _, err := getClient(conf); err != nil {
+		return err
+	}
+	elasticVersion, err := version.NewVersion(conf.esVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, fieldType := range types {
+		minimalVersion, err := version.NewVersion(mappingFieldTypeMinimalVersions[fieldType])
+		if err != nil {
+			return err
+		}
+		if elasticVersion.LessThan(minimalVersion) {
+			return fmt.Errorf("mappings field type %q is only supported from ElasticSearch >= %s, got version %s", fieldType, minimalVersion.String(), elasticVersion.String())
+		}
+	}
+	return nil
+}
+
+// validateMappingIndexPrefix namespaces the throwaway indices created to
+// validate mappings, so that they can be told apart if one is left behind
+const validateMappingIndexPrefix = "terraform-provider-elasticsearch-validate-mapping-"
+
+// validateIndexMappings checks that mappings define properties and are
+// accepted by the cluster, by creating a throwaway index from them along with
+// the settings which apply to mappings
+func validateIndexMappings(d *schema.ResourceDiff, mappingsJSON string, meta interface{}) (err error) {
+	var mappings map[string]interface{}
+	if err := json.Unmarshal([]byte(mappingsJSON), &mappings); err != nil {
+		return fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	if _, ok := normalizeIndexMappings(mappings)["properties"].(map[string]interface{}); !ok {
+		return fmt.Errorf("mappings must define `properties`")
+	}
+
+	settings := map[string]interface{}{
+		"number_of_shards":   1,
+		"number_of_replicas": 0,
+	}
+	for _, key := range settingsKeys {
+		if !strings.HasPrefix(key, "mapping.") && !strings.HasPrefix(key, "sort.") {
+			continue
+		}
+		name := settingSchemaName(key)
+		getOk := d.GetOk
+		if configSchema[name].Type == schema.TypeBool {
+			getOk = d.GetOkExists
+		}
+		if value, ok := getOk(name); ok {
+			settings[key] = value
+		}
+	}
+	body := map[string]interface{}{
+		"settings": settings,
+		"mappings": mappings,
+	}
+
+	var (
+		name        = resource.PrefixedUniqueId(validateMappingIndexPrefix + acctest.RandString(8) + "-")
+		ctx         = context.Background()
+		deleteIndex func() error
+	)
+	// the index is deleted even if validating panics
+	defer func() {
+		if deleteIndex == nil {
+			return
+		}
+		if deleteErr := deleteIndex(); deleteErr != nil && err == nil {
+			err = fmt.Errorf("fail to delete the index %s created to validate the mappings: %v", name, deleteErr)
+		}
+	}()
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		setIndexMappingsLayout(body, false)
+		if _, err = client.CreateIndex(name).BodyJson(body).Do(ctx); err == nil {
+			deleteIndex = func() error {
+				_, err := client.DeleteIndex(name).Do(ctx)
+				return err
+			}
+		}
+	case *elastic6.Client:
+		setIndexMappingsLayout(body, true)
+		if _, err = client.CreateIndex(name).BodyJson(body).Do(ctx); err == nil {
+			deleteIndex = func() error {
+				_, err := client.DeleteIndex(name).Do(ctx)
+				return err
+			}
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		if _, err = elastic5Client.CreateIndex(name).BodyJson(body).Do(ctx); err == nil {
+			deleteIndex = func() error {
+				_, err := elastic5Client.DeleteIndex(name).Do(ctx)
+				return err
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("mappings are invalid: %v", err)
+	}
+	return nil
+}
+
+// mappingFieldTypes returns the distinct field types of a typeless mapping,
+// including those of object, nested and multi-fields and dynamic templates
+func mappingFieldTypes(mappings map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var walk func(field map[string]interface{})
+	walk = func(field map[string]interface{}) {
+		if fieldType, ok := field["type"].(string); ok {
+			seen[fieldType] = true
+		}
+		for _, key := range []string{"properties", "fields"} {
+			if children, ok := field[key].(map[string]interface{}); ok {
+				for _, child := range children {
+					if child, ok := child.(map[string]interface{}); ok {
+						walk(child)
+					}
+				}
+			}
+		}
+	}
+	walk(mappings)
+
+	if templates, ok := mappings["dynamic_templates"].([]interface{}); ok {
+		for _, template := range templates {
+			named, _ := template.(map[string]interface{})
+			for _, t := range named {
+				if t, ok := t.(map[string]interface{}); ok {
+					if mapping, ok := t["mapping"].(map[string]interface{}); ok {
+						walk(mapping)
+					}
+				}
+			}
+		}
+	}
+
+	types := make([]string, 0, len(seen))
+	for fieldType := range seen {
+		types = append(types, fieldType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func resourceElasticsearchIndexCreate(d *schema.ResourceData, meta interface{}) error {
+	var (
+		name     = d.Get("name").(string)
+		settings = settingsFromIndexResourceData(d)
+		body     = make(map[string]interface{})
+		ctx      = context.Background()
+		err      error
+	)
+	if settingsJSON, ok, err := indexJSONFromFile(d, "settings"); err != nil {
+		return err
+	} else if ok {
+		extra, err := indexSettingsFromJSON(settingsJSON)
+		if err != nil {
+			return fmt.Errorf("fail to unmarshal: %v", err)
+		}
+		for key, value := range extra {
+			settings[key] = value
+		}
+	}
+	if len(settings) > 0 {
+		body["settings"] = settings
+	}
+
+	if aliasJSON, ok, err := indexJSON(d, "aliases"); err != nil {
+		return err
+	} else if ok {
+		var aliases map[string]interface{}
+		err = json.Unmarshal([]byte(aliasJSON), &aliases)
+		if err != nil {
+			return fmt.Errorf("fail to unmarshal: %v", err)
+		}
+		body["aliases"] = aliases
+	}
+
+	mappingsJSON, ok, err := indexJSON(d, "mappings")
+	if err != nil {
+		return err
+	}
+	if ok {
+		var mappings map[string]interface{}
+		err = json.Unmarshal([]byte(mappingsJSON), &mappings)
+		if err != nil {
+			return fmt.Errorf("fail to unmarshal: %v", err)
+		}
+		body["mappings"] = mappings
+	}
+
+	// if date math is used, we need to pass the resolved name along to the read
+	// so we can pull the right result from the response
+	var resolvedName, existingName string
+
+	// Note: the CreateIndex call handles URL encoding under the hood to handle
+	// non-URL friendly characters and functionality like date math
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	includeTypeName, includeTypeNameSet := d.GetOkExists("include_type_name")
+	typed, includeTypeNameParam, err := indexIncludeTypeName(meta.(*ProviderConf).esVersion, includeTypeName, includeTypeNameSet)
+	if err != nil {
+		return err
+	}
+	if aliases, ok := body["aliases"].(map[string]interface{}); ok {
+		if err := checkIndexWriteAliases(esClient, name, aliases); err != nil {
+			return err
+		}
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		// ES 7 only accepts a typed mapping with include_type_name, which
+		// discards the type
+		setIndexMappingsLayout(body, typed)
+		var (
+			resp       *elastic7.IndicesCreateResult
+			requestErr error
+		)
+		if includeTypeNameParam {
+			resp, requestErr = elastic7CreateIndexWithTypeName(ctx, client, name, body, meta.(*ProviderConf).masterTimeout)
+		} else {
+			resp, requestErr = client.CreateIndex(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
+		}
+		err = requestErr
+		if err == nil {
+			resolvedName = resp.Index
+		} else if e, ok := err.(*elastic7.Error); ok && e.Details != nil && isIndexAlreadyExists(e.Details.Type) {
+			existingName = e.Details.Index
+		}
+
+	case *elastic6.Client:
+		setIndexMappingsLayout(body, typed)
+		service := client.CreateIndex(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout)
+		if includeTypeNameParam {
+			service = service.IncludeTypeName(typed)
+		}
+		resp, requestErr := service.Do(ctx)
+		err = requestErr
+		if err == nil {
+			resolvedName = resp.Index
+		} else if e, ok := err.(*elastic6.Error); ok && e.Details != nil && isIndexAlreadyExists(e.Details.Type) {
+			existingName = e.Details.Index
+		}
+
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		resp, requestErr := elastic5Client.CreateIndex(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
+		err = requestErr
+		if err == nil {
+			resolvedName = resp.Index
+		} else if e, ok := err.(*elastic5.Error); ok && e.Details != nil && isIndexAlreadyExists(e.Details.Type) {
+			existingName = e.Details.Index
+		}
+
+	}
+
+	if err != nil && existingName != "" && d.Get("adopt_existing").(bool) {
+		log.Printf("[INFO] Index (%s) already exists, adopting it", existingName)
+		resolvedName = existingName
+		err = nil
+	}
+
+	if err == nil {
+		// Let terraform know the resource was created
+		d.SetId(resolvedName)
+		if err := waitForIndexStatus(esClient, resolvedName, d.Get("wait_for_status").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+		if documents, ok := d.GetOk("documents"); ok {
+			if err := bulkIndexDocuments(esClient, resolvedName, mappingsJSON, documents.(string), d.Get("documents_refresh").(string)); err != nil {
+				return err
+			}
+		}
+		return resourceElasticsearchIndexRead(d, meta)
+	}
+	return err
+}
+
+// elastic7CreateIndexWithTypeName creates an index from typed mappings with
+// include_type_name, which the create index service of the client lacks
+func elastic7CreateIndexWithTypeName(ctx context.Context, client *elastic7.Client, name string, body map[string]interface{}, masterTimeout string) (*elastic7.IndicesCreateResult, error) {
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for index %s: %+v", name, err)
+	}
+
+	params := url.Values{"include_type_name": []string{"true"}}
+	if masterTimeout != "" {
+		params.Set("master_timeout", masterTimeout)
+	}
+	res, err := client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "PUT",
+		Path:   path,
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(elastic7.IndicesCreateResult)
+	if err := json.Unmarshal(res.Body, resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, res.Body)
+	}
+	return resp, nil
+}
+
+// waitForIndexStatus waits until a new index is visible in the cluster state
+// with the given health, so that it can be read reliably right after creation
+func waitForIndexStatus(esClient interface{}, name, status string, timeout time.Duration) error {
+	if status == "none" {
+		return nil
+	}
+
+	ctx := context.Background()
+	return resource.Retry(timeout, func() *resource.RetryError {
+		var (
+			timedOut bool
+			retry    bool
+			err      error
+		)
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			var resp *elastic7.ClusterHealthResponse
+			resp, err = client.ClusterHealth().Index(name).WaitForStatus(status).Timeout("10s").Do(ctx)
+			retry = elastic7.IsNotFound(err) || elastic7.IsTimeout(err)
+			timedOut = err == nil && resp.TimedOut
+		case *elastic6.Client:
+			var resp *elastic6.ClusterHealthResponse
+			resp, err = client.ClusterHealth().Index(name).WaitForStatus(status).Timeout("10s").Do(ctx)
+			retry = elastic6.IsNotFound(err) || elastic6.IsTimeout(err)
+			timedOut = err == nil && resp.TimedOut
+		default:
+			elastic5Client := client.(*elastic5.Client)
+			var resp *elastic5.ClusterHealthResponse
+			resp, err = elastic5Client.ClusterHealth().Index(name).WaitForStatus(status).Timeout("10s").Do(ctx)
+			retry = elastic5.IsNotFound(err) || elastic5.IsTimeout(err)
+			timedOut = err == nil && resp.TimedOut
+		}
+
+		if retry || timedOut {
+			return resource.RetryableError(fmt.Errorf("index %q is not %s yet", name, status))
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
+// indexRolloverAlias returns the rollover alias of the ILM or ISM policy of an
+// index from its nested settings
+func indexRolloverAlias(settings map[string]interface{}) (string, bool) {
+	if lifecycle, ok := settings["lifecycle"].(map[string]interface{}); ok {
+		alias, ok := lifecycle["rollover_alias"].(string)
+		return alias, ok
+	}
+	if opendistro, ok := settings["opendistro"].(map[string]interface{}); ok {
+		if ism, ok := opendistro["index_state_management"].(map[string]interface{}); ok {
+			alias, ok := ism["rollover_alias"].(string)
+			return alias, ok
+		}
+	}
+	return "", false
+}
+
+// waitForIndexRolloverAlias waits until the policy of a new index is attached,
+// as the settings of a matching index template may only be applied to it
+// after it's created
+func waitForIndexRolloverAlias(esClient interface{}, name string, timeout time.Duration) error {
+	ctx := context.Background()
+	return resource.Retry(timeout, func() *resource.RetryError {
+		var (
+			settings map[string]interface{}
+			err      error
+		)
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			var resp map[string]*elastic7.IndicesGetSettingsResponse
+			resp, err = client.IndexGetSettings(name).Do(ctx)
+			if err == nil && resp[name] != nil {
+				settings, _ = resp[name].Settings["index"].(map[string]interface{})
+			}
+		case *elastic6.Client:
+			var resp map[string]*elastic6.IndicesGetSettingsResponse
+			resp, err = client.IndexGetSettings(name).Do(ctx)
+			if err == nil && resp[name] != nil {
+				settings, _ = resp[name].Settings["index"].(map[string]interface{})
+			}
+		default:
+			elastic5Client := client.(*elastic5.Client)
+			var resp map[string]*elastic5.IndicesGetSettingsResponse
+			resp, err = elastic5Client.IndexGetSettings(name).Do(ctx)
+			if err == nil && resp[name] != nil {
+				settings, _ = resp[name].Settings["index"].(map[string]interface{})
+			}
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if _, ok := indexRolloverAlias(settings); !ok {
+			return resource.RetryableError(fmt.Errorf("the policy of index %q has no rollover alias yet", name))
+		}
+		return nil
+	})
+}
+
+func settingsFromIndexResourceData(d *schema.ResourceData) map[string]interface{} {
+	settings := make(map[string]interface{})
+	for _, key := range settingsKeys {
+		if raw, ok := settingFromResourceData(d, settingSchemaName(key)); ok {
+			settings[key] = raw
+		}
+	}
+	if raw, ok := d.GetOk("routing_allocation"); ok {
+		for key, value := range raw.(map[string]interface{}) {
+			settings[routingAllocationPrefix+key] = value
+		}
+	}
+	for key, value := range searchLimitsFromResourceData(d.Get("search_limits")) {
+		settings[key] = value
+	}
+	// the JSON is validated, so it can't fail to unmarshal
+	extra, _ := indexSettingsFromJSON(d.Get("settings").(string))
+	for key, value := range extra {
+		settings[key] = value
+	}
+	return settings
+}
+
+// searchLimitsFromResourceData returns the settings of a `search_limits`
+// block, leaving out the unset limits
+func searchLimitsFromResourceData(raw interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	list, _ := raw.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return settings
+	}
+	for name, value := range list[0].(map[string]interface{}) {
+		if key, ok := searchLimitsSettingsKeys[name]; ok && value.(int) != 0 {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+func indexResourceDataFromSettings(settings map[string]interface{}, d *schema.ResourceData) {
+	flattened := flattenMap(settings)
+	// the limits are read into either the `search_limits` block, when it is
+	// used, or the flat attributes
+	_, searchLimits := d.GetOk("search_limits")
+	for _, key := range settingsKeys {
+		if isIgnoredSetting(d, key) {
+			continue
+		}
+		schemaName := settingSchemaName(key)
+		if _, ok := searchLimitsSettingsKeys[schemaName]; ok && searchLimits {
+			continue
+		}
+		raw, ok := flattened[key]
+		if configSchema[schemaName].Type == schema.TypeList {
+			raw = settingListFromFlattened(flattened, key)
+		} else if list := settingListFromFlattened(flattened, key); !ok && len(list) > 0 {
+			raw = list
+		}
+		// a detached pipeline is unset, unless it is explicitly configured so
+		if pipelineSettingsKeys[key] && raw == noPipeline && d.Get(schemaName).(string) != noPipeline {
+			raw = nil
+		}
+		value, err := settingValue(configSchema[schemaName].Type, raw)
+		if err != nil {
+			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+			continue
+		}
+		err = d.Set(schemaName, value)
+		if err != nil {
+			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+		}
+	}
+
+	allocation := make(map[string]interface{})
+	configured := d.Get("routing_allocation").(map[string]interface{})
+	for key, value := range flattened {
+		if !strings.HasPrefix(key, routingAllocationPrefix) {
+			continue
+		}
+		filter := strings.TrimPrefix(key, routingAllocationPrefix)
+		if !isRoutingAllocationFilter(filter) {
+			continue
+		}
+		if isIgnoredSetting(d, key) {
+			continue
+		}
+		// ES >= 7.10 injects a tier preference on every index, only track it
+		// when it is explicitly managed
+		if _, ok := configured[filter]; !ok && strings.HasSuffix(filter, "._tier_preference") {
+			continue
+		}
+		if list, ok := value.([]interface{}); ok {
+			value = settingStringFromList(list)
+		}
+		allocation[filter] = value
+	}
+	// keep whatever is in state for externally managed filters
+	for filter, value := range configured {
+		if isIgnoredSetting(d, routingAllocationPrefix+filter) {
+			allocation[filter] = value
+		}
+	}
+	err := d.Set("routing_allocation", allocation)
+	if err != nil {
+		log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+	}
+
+	if raw, ok := d.GetOk("settings"); ok {
+		configuredSettings, err := indexSettingsFromJSON(raw.(string))
+		if err != nil {
+			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+		} else if err := d.Set("settings", indexSettingsJSONFromFlattened(configuredSettings, flattened, d)); err != nil {
+			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+		}
+	}
+
+	if searchLimits {
+		configuredLimits, _ := d.Get("search_limits.0").(map[string]interface{})
+		limits := make(map[string]interface{})
+		for name, key := range searchLimitsSettingsKeys {
+			// keep whatever is in state for externally managed limits
+			if isIgnoredSetting(d, key) {
+				limits[name] = configuredLimits[name]
+				continue
+			}
+			value, err := settingValue(schema.TypeInt, flattened[key])
+			if err != nil {
+				log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+				continue
+			}
+			if value != nil {
+				limits[name] = value
+			}
+		}
+		err := d.Set("search_limits", []interface{}{limits})
+		if err != nil {
+			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+		}
+	}
+}
+
+// isIndexAlreadyExists returns whether an error type is raised for an index
+// that already exists, ES 5 uses its own type
+func isIndexAlreadyExists(errorType string) bool {
+	return errorType == "resource_already_exists_exception" || errorType == "index_already_exists_exception"
+}
+
+// isIgnoredSetting returns whether a settings key is listed in
+// `ignore_settings`, with or without the `index.` prefix
+func isIgnoredSetting(d *schema.ResourceData, key string) bool {
+	ignored := d.Get("ignore_settings").(*schema.Set)
+	return ignored.Contains(key) || ignored.Contains("index."+key)
+}
+
+// settingSchemaName returns the attribute name for a settings key, e.g.
+// `mapping.total_fields.limit` is managed as `mapping_total_fields_limit`
+func settingSchemaName(key string) string {
+	if name, ok := settingsSchemaNames[key]; ok {
+		return name
+	}
+	return strings.Replace(key, ".", "_", -1)
+}
+
+// noPipeline detaches the default or final pipeline of an index, which
+// neither an empty value nor null do
+const noPipeline = "_none"
+
+// pipelineSettingsKeys are the settings which are detached with noPipeline
+var pipelineSettingsKeys = map[string]bool{
+	"default_pipeline": true,
+	"final_pipeline":   true,
+}
+
+// settingFromResourceData returns a configured setting, a boolean set to false
+// counts as configured, an empty string, e.g. `number_of_replicas = ""`, doesn't
+func settingFromResourceData(d *schema.ResourceData, schemaName string) (interface{}, bool) {
+	if configSchema[schemaName].Type == schema.TypeBool {
+		return d.GetOkExists(schemaName)
+	}
+	return d.GetOk(schemaName)
+}
+
+// settingValue converts a setting, which ES returns as a string, or as a JSON
+// number or boolean, to the type of its attribute, a single valued list, e.g.
+// of a setting set as a list outside of the provider, is read as its value
+func settingValue(valueType schema.ValueType, value interface{}) (interface{}, error) {
+	if list, ok := value.([]interface{}); ok && valueType != schema.TypeList {
+		if len(list) != 1 {
+			return nil, fmt.Errorf("expected a single value, got %v", list)
+		}
+		value = list[0]
+	}
+	if value == nil {
+		return nil, nil
+	}
+	switch valueType {
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case string:
+			return strconv.Atoi(v)
+		case float64:
+			return int(v), nil
+		}
+	case schema.TypeBool:
+		if s, ok := value.(string); ok {
+			return strconv.ParseBool(s)
+		}
+	case schema.TypeString:
+		switch value.(type) {
+		case float64, bool:
+			return fmt.Sprint(value), nil
+		}
+	}
+	return value, nil
+}
+
+// settingListFromFlattened returns a list setting, which ES returns either as
+// an array, as a single value when it was set as one, or, as ES 5 does, as an
+// object keyed by the position in the list
+func settingListFromFlattened(flattened map[string]interface{}, key string) []interface{} {
+	switch value := flattened[key].(type) {
+	case []interface{}:
+		return value
+	case string:
+		return []interface{}{value}
+	}
+
+	var list []interface{}
+	for i := 0; ; i++ {
+		value, ok := flattened[fmt.Sprintf("%s.%d", key, i)]
+		if !ok {
+			return list
+		}
+		list = append(list, value)
+	}
+}
+
+// indexSettingsFromJSON normalizes the `settings` JSON into flattened keys
+// without the `index.` prefix and the string values ES returns, leaving out
+// null values
+func indexSettingsFromJSON(settingsJSON string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if settingsJSON == "" {
+		return settings, nil
 	}
 
-	if aliasJSON, ok := d.GetOk("aliases"); ok {
-		var aliases map[string]interface{}
-		bytes := []byte(aliasJSON.(string))
-		err = json.Unmarshal(bytes, &aliases)
-		if err != nil {
-			return fmt.Errorf("fail to unmarshal: %v", err)
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(settingsJSON), &raw); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	for key, value := range flattenMap(raw) {
+		if value != nil {
+			settings[strings.TrimPrefix(key, "index.")] = indexSettingString(value)
 		}
-		body["aliases"] = aliases
 	}
+	return settings, nil
+}
 
-	if mappingsJSON, ok := d.GetOk("mappings"); ok {
-		var mappings map[string]interface{}
-		bytes := []byte(mappingsJSON.(string))
-		err = json.Unmarshal(bytes, &mappings)
-		if err != nil {
-			return fmt.Errorf("fail to unmarshal: %v", err)
+// indexSettingString converts a setting value to the string, or list of
+// strings, ES returns for it
+func indexSettingString(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = indexSettingString(item)
 		}
-		body["mappings"] = mappings
+		return list
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
 	}
+}
 
-	// if date math is used, we need to pass the resolved name along to the read
-	// so we can pull the right result from the response
-	var resolvedName string
+// indexSettingsJSONFromFlattened reads back the configured `settings` keys
+// from the settings of the index, keeping the configured value of ignored
+// keys, and dropping the keys which aren't set anymore
+func indexSettingsJSONFromFlattened(configured, flattened map[string]interface{}, d *schema.ResourceData) string {
+	settings := make(map[string]interface{})
+	for key, value := range configured {
+		if isIgnoredSetting(d, key) {
+			settings[key] = value
+			continue
+		}
+		if _, isList := value.([]interface{}); isList {
+			if list := settingListFromFlattened(flattened, key); len(list) > 0 {
+				settings[key] = indexSettingString(list)
+			}
+		} else if actual, ok := flattened[key]; ok && actual != nil {
+			settings[key] = indexSettingString(actual)
+		}
+	}
+	if len(settings) == 0 {
+		return ""
+	}
+	// json.Marshal sorts the keys, which keeps the state stable
+	bytes, _ := json.Marshal(settings)
+	return string(bytes)
+}
 
-	// Note: the CreateIndex call handles URL encoding under the hood to handle
-	// non-URL friendly characters and functionality like date math
-	esClient, err := getClient(meta.(*ProviderConf))
+// changedStaticIndexSetting returns a static setting which differs between two
+// `settings` JSONs, as it can't be updated on an open index, while the
+// dynamic ones are updated in place
+func changedStaticIndexSetting(oldJSON, newJSON string, elasticVersion *version.Version) (string, bool) {
+	oldSettings, err := indexSettingsFromJSON(oldJSON)
 	if err != nil {
-		return err
+		return "", false
+	}
+	newSettings, err := indexSettingsFromJSON(newJSON)
+	if err != nil {
+		return "", false
 	}
-	switch client := esClient.(type) {
-	case *elastic7.Client:
-		resp, requestErr := client.CreateIndex(name).BodyJson(body).Do(ctx)
-		err = requestErr
-		if err == nil {
-			resolvedName = resp.Index
-		}
 
-	case *elastic6.Client:
-		resp, requestErr := client.CreateIndex(name).BodyJson(body).Do(ctx)
-		err = requestErr
-		if err == nil {
-			resolvedName = resp.Index
+	var changed []string
+	for key, value := range newSettings {
+		if !reflect.DeepEqual(oldSettings[key], value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldSettings {
+		if _, ok := newSettings[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	for _, key := range changed {
+		if isStaticIndexSetting(key, elasticVersion) {
+			return key, true
 		}
+	}
+	return "", false
+}
 
-	default:
-		elastic5Client := client.(*elastic5.Client)
-		resp, requestErr := elastic5Client.CreateIndex(name).BodyJson(body).Do(ctx)
-		err = requestErr
-		if err == nil {
-			resolvedName = resp.Index
+// isStaticIndexSetting returns whether a settings key of the `settings` JSON
+// can only be set on creation or on a closed index on a version of ES
+func isStaticIndexSetting(key string, elasticVersion *version.Version) bool {
+	for static, versions := range staticIndexSettings {
+		if key != static && !(strings.HasSuffix(static, ".") && strings.HasPrefix(key, static)) {
+			continue
+		}
+		if versions.since != "" {
+			if since, err := version.NewVersion(versions.since); err == nil && elasticVersion.LessThan(since) {
+				return false
+			}
 		}
+		if versions.until != "" {
+			if until, err := version.NewVersion(versions.until); err == nil && !elasticVersion.LessThan(until) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
 
+// settingStringFromList joins a list setting into the comma separated string
+// which ES accepts for it as well
+func settingStringFromList(list []interface{}) string {
+	values := make([]string, len(list))
+	for i, value := range list {
+		values[i] = fmt.Sprint(value)
 	}
+	return strings.Join(values, ",")
+}
 
-	if err == nil {
-		// Let terraform know the resource was created
-		d.SetId(resolvedName)
-		return resourceElasticsearchIndexRead(d, meta)
+func isRoutingAllocationFilter(key string) bool {
+	for _, filter := range routingAllocationFilters {
+		if strings.HasPrefix(key, filter) && len(key) > len(filter) {
+			return true
+		}
 	}
-	return err
+	return false
 }
 
-func settingsFromIndexResourceData(d *schema.ResourceData) map[string]interface{} {
-	settings := make(map[string]interface{})
+// validateIndexSettingsJSON checks that the `settings` JSON is an object
+// which doesn't set settings managed by dedicated attributes or the cluster
+func validateIndexSettingsJSON(i interface{}, k string) (warnings []string, errors []error) {
+	settings, err := indexSettingsFromJSON(i.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object: %v", k, err))
+		return warnings, errors
+	}
+
+	managed := make(map[string]string)
 	for _, key := range settingsKeys {
-		if raw, ok := d.GetOk(key); ok {
-			settings[key] = raw
+		managed[key] = settingSchemaName(key)
+	}
+	for name, key := range searchLimitsSettingsKeys {
+		managed[key] = "search_limits." + name
+	}
+	for key := range settings {
+		if name, ok := managed[key]; ok {
+			errors = append(errors, fmt.Errorf("%q: setting %q is managed by the `%s` attribute", k, key, name))
+		} else if strings.HasPrefix(key, routingAllocationPrefix) && isRoutingAllocationFilter(strings.TrimPrefix(key, routingAllocationPrefix)) {
+			errors = append(errors, fmt.Errorf("%q: setting %q is managed by the `routing_allocation` attribute", k, key))
+		}
+		for _, internal := range internalIndexSettings {
+			if key == internal {
+				errors = append(errors, fmt.Errorf("%q: setting %q is set by the cluster", k, key))
+			}
 		}
 	}
-	return settings
+	return warnings, errors
 }
 
-func indexResourceDataFromSettings(settings map[string]interface{}, d *schema.ResourceData) {
-	for _, key := range settingsKeys {
-		err := d.Set(key, settings[key])
-		if err != nil {
-			log.Printf("[INFO] indexResourceDataFromSettings: %+v", err)
+func validateRoutingAllocation(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be map", k))
+		return warnings, errors
+	}
+
+	for key := range v {
+		if !isRoutingAllocationFilter(key) {
+			errors = append(errors, fmt.Errorf("%q: key %q must be of the form require.<attribute>, include.<attribute> or exclude.<attribute>", k, key))
 		}
 	}
+
+	return warnings, errors
 }
 
 func resourceElasticsearchIndexDelete(d *schema.ResourceData, meta interface{}) error {
 	var (
 		name = d.Id()
-		ctx  = context.Background()
 		err  error
 	)
 
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	if alias, ok := d.GetOk("rollover_alias"); ok {
 		name = getWriteIndexByAlias(alias.(string), d, meta)
 	}
 
-	// check to see if there are documents in the index
-	allowed := allowIndexDestroy(name, d, meta)
-	if !allowed {
-		return fmt.Errorf("There are documents in the index (or the index could not be , set force_destroy to true to allow destroying.")
-	}
-
 	esClient, err := getClient(meta.(*ProviderConf))
 	if err != nil {
 		return err
 	}
+
+	// check to see if there are documents in the index, unless it's deleted
+	// regardless
+	if !d.Get("force_destroy").(bool) && !allowIndexDestroy(ctx, esClient, name) {
+		return fmt.Errorf("There are documents in the index (or the index could not be counted), set force_destroy to true to allow destroying.")
+	}
+
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.DeleteIndex(name).Do(ctx)
+		_, err = client.DeleteIndex(name).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 
 	case *elastic6.Client:
-		_, err = client.DeleteIndex(name).Do(ctx)
+		_, err = client.DeleteIndex(name).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		_, err = elastic5Client.DeleteIndex(name).Do(ctx)
+		_, err = elastic5Client.DeleteIndex(name).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 	}
 
-	return err
-}
+	if err != nil || !d.Get("wait_for_deletion").(bool) {
+		return err
+	}
 
-func allowIndexDestroy(indexName string, d *schema.ResourceData, meta interface{}) bool {
-	force := d.Get("force_destroy").(bool)
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		var exists bool
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			exists, err = client.IndexExists(name).Do(ctx)
+		case *elastic6.Client:
+			exists, err = client.IndexExists(name).Do(ctx)
+		default:
+			elastic5Client := client.(*elastic5.Client)
+			exists, err = elastic5Client.IndexExists(name).Do(ctx)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if exists {
+			return resource.RetryableError(fmt.Errorf("index %q still exists", name))
+		}
+		return nil
+	})
+}
 
+// allowIndexDestroy returns whether an index is empty, and so can be
+// destroyed without force_destroy
+func allowIndexDestroy(ctx context.Context, esClient interface{}, indexName string) bool {
 	var (
-		ctx   = context.Background()
 		count int64
 		err   error
 	)
-	esClient, err := getClient(meta.(*ProviderConf))
-	if err != nil {
-		return false
-	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
 		count, err = client.Count(indexName).Do(ctx)
@@ -288,17 +1970,86 @@ func allowIndexDestroy(indexName string, d *schema.ResourceData, meta interface{
 		return false
 	}
 
-	if count > 0 && !force {
-		return false
-	}
-	return true
+	return count == 0
 }
 
 func resourceElasticsearchIndexUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("documents") {
+		if documents, ok := d.GetOk("documents"); ok {
+			esClient, err := getClient(meta.(*ProviderConf))
+			if err != nil {
+				return err
+			}
+			mappingsJSON, _, err := indexJSON(d, "mappings")
+			if err != nil {
+				return err
+			}
+			if err := bulkIndexDocuments(esClient, d.Id(), mappingsJSON, documents.(string), d.Get("documents_refresh").(string)); err != nil {
+				return err
+			}
+		}
+	}
+
 	settings := make(map[string]interface{})
 	for _, key := range settingsKeys {
-		if d.HasChange(key) {
-			settings[key] = d.Get(key)
+		schemaName := settingSchemaName(key)
+		if d.HasChange(schemaName) && !isIgnoredSetting(d, key) {
+			// null out removed settings so the cluster falls back to its defaults
+			if value, ok := settingFromResourceData(d, schemaName); ok {
+				settings[key] = value
+			} else if pipelineSettingsKeys[key] {
+				settings[key] = noPipeline
+			} else {
+				settings[key] = nil
+			}
+		}
+	}
+	if d.HasChange("routing_allocation") {
+		o, n := d.GetChange("routing_allocation")
+		// null out removed filters so the cluster falls back to its defaults
+		for key := range o.(map[string]interface{}) {
+			if !isIgnoredSetting(d, routingAllocationPrefix+key) {
+				settings[routingAllocationPrefix+key] = nil
+			}
+		}
+		for key, value := range n.(map[string]interface{}) {
+			if !isIgnoredSetting(d, routingAllocationPrefix+key) {
+				settings[routingAllocationPrefix+key] = value
+			}
+		}
+	}
+
+	if d.HasChange("search_limits") {
+		o, n := d.GetChange("search_limits")
+		// null out removed limits so the cluster falls back to its defaults,
+		// unless they moved to a flat attribute
+		for key := range searchLimitsFromResourceData(o) {
+			if _, ok := settings[key]; !ok && !isIgnoredSetting(d, key) {
+				settings[key] = nil
+			}
+		}
+		for key, value := range searchLimitsFromResourceData(n) {
+			if !isIgnoredSetting(d, key) {
+				settings[key] = value
+			}
+		}
+	}
+
+	if d.HasChange("settings") {
+		o, n := d.GetChange("settings")
+		oldSettings, _ := indexSettingsFromJSON(o.(string))
+		newSettings, _ := indexSettingsFromJSON(n.(string))
+		// null out removed settings so the cluster falls back to its defaults,
+		// and only send the changed ones
+		for key := range oldSettings {
+			if _, ok := newSettings[key]; !ok && !isIgnoredSetting(d, key) {
+				settings[key] = nil
+			}
+		}
+		for key, value := range newSettings {
+			if !reflect.DeepEqual(oldSettings[key], value) && !isIgnoredSetting(d, key) {
+				settings[key] = value
+			}
 		}
 	}
 
@@ -327,14 +2078,14 @@ func resourceElasticsearchIndexUpdate(d *schema.ResourceData, meta interface{})
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.IndexPutSettings(name).BodyJson(body).Do(ctx)
+		_, err = client.IndexPutSettings(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 
 	case *elastic6.Client:
-		_, err = client.IndexPutSettings(name).BodyJson(body).Do(ctx)
+		_, err = client.IndexPutSettings(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		_, err = elastic5Client.IndexPutSettings(name).BodyJson(body).Do(ctx)
+		_, err = elastic5Client.IndexPutSettings(name).BodyJson(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(ctx)
 	}
 
 	if err == nil {
@@ -343,40 +2094,269 @@ func resourceElasticsearchIndexUpdate(d *schema.ResourceData, meta interface{})
 	return err
 }
 
-func getWriteIndexByAlias(alias string, d *schema.ResourceData, meta interface{}) string {
+// bulkIndexDocuments indexes a JSON array of documents, using their `_id`
+// key as ID
+func bulkIndexDocuments(esClient interface{}, index, mappingsJSON, documentsJSON, refresh string) error {
+	var documents []map[string]interface{}
+	if err := json.Unmarshal([]byte(documentsJSON), &documents); err != nil {
+		return fmt.Errorf("fail to unmarshal documents: %v", err)
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	// ES < 7 requires the mapping type of the documents
+	var typeName string
+	if mappingsJSON != "" {
+		var mappings map[string]interface{}
+		if err := json.Unmarshal([]byte(mappingsJSON), &mappings); err != nil {
+			return fmt.Errorf("fail to unmarshal: %v", err)
+		}
+		typeName, _ = mappingType(mappings)
+	}
+
 	var (
-		index   = d.Id()
-		ctx     = context.Background()
-		columns = []string{"index", "is_write_index"}
+		ctx    = context.Background()
+		failed []string
 	)
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		bulk := client.Bulk().Index(index).Refresh(refresh)
+		for _, document := range documents {
+			id, source := documentIDAndSource(document)
+			bulk.Add(elastic7.NewBulkIndexRequest().Id(id).Doc(source))
+		}
+		resp, err := bulk.Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Failed() {
+			failed = append(failed, fmt.Sprintf("%s: %s", item.Id, item.Error.Reason))
+		}
+
+	case *elastic6.Client:
+		if typeName == "" {
+			typeName = "_doc"
+		}
+		bulk := client.Bulk().Index(index).Type(typeName).Refresh(refresh)
+		for _, document := range documents {
+			id, source := documentIDAndSource(document)
+			bulk.Add(elastic6.NewBulkIndexRequest().Id(id).Doc(source))
+		}
+		resp, err := bulk.Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Failed() {
+			failed = append(failed, fmt.Sprintf("%s: %s", item.Id, item.Error.Reason))
+		}
+
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		// types can't start with an underscore in ES 5
+		if typeName == "" {
+			typeName = "doc"
+		}
+		bulk := elastic5Client.Bulk().Index(index).Type(typeName).Refresh(refresh)
+		for _, document := range documents {
+			id, source := documentIDAndSource(document)
+			bulk.Add(elastic5.NewBulkIndexRequest().Id(id).Doc(source))
+		}
+		resp, err := bulk.Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Failed() {
+			failed = append(failed, fmt.Sprintf("%s: %s", item.Id, item.Error.Reason))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to index %d of %d documents into %s: %s", len(failed), len(documents), index, strings.Join(failed, ", "))
+	}
+	log.Printf("[INFO] Indexed %d documents into %s", len(documents), index)
+	return nil
+}
+
+// documentIDAndSource splits the `_id` key, a metadata field which can't be
+// part of the source, from a document
+func documentIDAndSource(document map[string]interface{}) (string, map[string]interface{}) {
+	id, ok := document["_id"]
+	if !ok {
+		return "", document
+	}
+	source := make(map[string]interface{}, len(document)-1)
+	for key, value := range document {
+		if key != "_id" {
+			source[key] = value
+		}
+	}
+	return fmt.Sprintf("%v", id), source
+}
+
+func validateIndexDocuments(i interface{}, k string) (warnings []string, errors []error) {
+	var documents []map[string]interface{}
+	if err := json.Unmarshal([]byte(i.(string)), &documents); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON array of objects: %v", k, err))
+	}
+	return
+}
+
+// validateIndexAliases checks that the aliases are a JSON object of alias
+// definitions, defining each alias once, as a duplicate, e.g. with a second
+// is_write_index, would silently override the first one
+func validateIndexAliases(i interface{}, k string) (warnings []string, errors []error) {
+	decoder := json.NewDecoder(strings.NewReader(i.(string)))
+	if t, err := decoder.Token(); err != nil || t != json.Delim('{') {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object of aliases", k))
+		return warnings, errors
+	}
+
+	seen := make(map[string]bool)
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%q must be a JSON object of aliases: %v", k, err))
+			return warnings, errors
+		}
+		alias, _ := t.(string)
+		var definition map[string]interface{}
+		if err := decoder.Decode(&definition); err != nil {
+			errors = append(errors, fmt.Errorf("%q: alias %q must be a JSON object: %v", k, alias, err))
+			return warnings, errors
+		}
+		if seen[alias] {
+			errors = append(errors, fmt.Errorf("%q: alias %q is defined more than once", k, alias))
+		}
+		seen[alias] = true
+		if isWriteIndex, ok := definition["is_write_index"]; ok {
+			if _, ok := isWriteIndex.(bool); !ok {
+				errors = append(errors, fmt.Errorf("%q: is_write_index of alias %q must be a boolean, got %v", k, alias, isWriteIndex))
+			}
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object of aliases: %v", k, err))
+	}
+	return warnings, errors
+}
+
+// checkIndexWriteAliases checks that the aliases an index is created as the
+// write index of don't already have another write index, as an alias can only
+// have one, which would break rolling it over
+func checkIndexWriteAliases(esClient interface{}, index string, aliases map[string]interface{}) error {
+	for alias, definition := range aliases {
+		definition, _ := definition.(map[string]interface{})
+		if isWriteIndex, _ := definition["is_write_index"].(bool); !isWriteIndex {
+			continue
+		}
+		_, writeIndex, err := getAliasIndices(esClient, alias)
+		if err != nil {
+			log.Printf("[INFO] checkIndexWriteAliases: %+v", err)
+			continue
+		}
+		if writeIndex != "" && writeIndex != index {
+			return fmt.Errorf("alias %s already has the write index %s, an alias can only have one write index", alias, writeIndex)
+		}
+	}
+	return nil
+}
+
+// configuredIndexAliases returns the read aliases which are configured, keeping
+// the configured definition of the ones removed from the index, e.g. moved by
+// elasticsearch_alias_swap, rather than recreating the index to restore them
+func configuredIndexAliases(configuredJSON string, aliases map[string]interface{}) map[string]interface{} {
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(configuredJSON), &configured); err != nil {
+		return aliases
+	}
+	result := make(map[string]interface{}, len(configured))
+	for alias, definition := range configured {
+		if read, ok := aliases[alias]; ok {
+			result[alias] = read
+		} else {
+			log.Printf("[WARN] Alias (%s) was removed from the index outside of Terraform, ignoring it", alias)
+			result[alias] = definition
+		}
+	}
+	return result
+}
+
+// preserveIndexAliasesWriteFlag keeps the configured is_write_index of the
+// aliases read back without one, e.g. before ES 6.4
+func preserveIndexAliasesWriteFlag(configuredJSON string, aliases map[string]interface{}) {
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(configuredJSON), &configured); err != nil {
+		return
+	}
+	for alias, definition := range configured {
+		definition, _ := definition.(map[string]interface{})
+		isWriteIndex, ok := definition["is_write_index"]
+		if !ok {
+			continue
+		}
+		read, ok := aliases[alias].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := read["is_write_index"]; !ok {
+			read["is_write_index"] = isWriteIndex
+		}
+	}
+}
+
+func getWriteIndexByAlias(alias string, d *schema.ResourceData, meta interface{}) string {
+	index := d.Id()
 
 	esClient, err := getClient(meta.(*ProviderConf))
 	if err != nil {
 		log.Printf("[INFO] getWriteIndexByAlias: %+v", err)
 		return index
 	}
+	_, writeIndex, err := getAliasIndices(esClient, alias)
+	if err != nil {
+		log.Printf("[INFO] getWriteIndexByAlias: %+v", err)
+		return index
+	}
+	if writeIndex != "" {
+		return writeIndex
+	}
+
+	return index
+}
+
+// getAliasIndices returns the indices of an alias, and its write index if one
+// is explicitly set
+func getAliasIndices(esClient interface{}, alias string) ([]string, string, error) {
+	var (
+		ctx        = context.Background()
+		columns    = []string{"index", "is_write_index"}
+		indices    []string
+		writeIndex string
+	)
+
 	switch client := esClient.(type) {
 	case *elastic7.Client:
 		r, err := client.CatAliases().Alias(alias).Columns(columns...).Do(ctx)
 		if err != nil {
-			log.Printf("[INFO] getWriteIndexByAlias: %+v", err)
-			return index
+			return nil, "", err
 		}
 		for _, column := range r {
+			indices = append(indices, column.Index)
 			if column.IsWriteIndex == "true" {
-				return column.Index
+				writeIndex = column.Index
 			}
 		}
 
 	case *elastic6.Client:
 		r, err := client.CatAliases().Alias(alias).Columns(columns...).Do(ctx)
 		if err != nil {
-			log.Printf("[INFO] getWriteIndexByAlias: %+v", err)
-			return index
+			return nil, "", err
 		}
 		for _, column := range r {
+			indices = append(indices, column.Index)
 			if column.IsWriteIndex == "true" {
-				return column.Index
+				writeIndex = column.Index
 			}
 		}
 
@@ -384,17 +2364,18 @@ func getWriteIndexByAlias(alias string, d *schema.ResourceData, meta interface{}
 		elastic5Client := client.(*elastic5.Client)
 		r, err := elastic5Client.CatAliases().Alias(alias).Columns(columns...).Do(ctx)
 		if err != nil {
-			log.Printf("[INFO] getWriteIndexByAlias: %+v", err)
-			return index
+			return nil, "", err
 		}
 		for _, column := range r {
+			indices = append(indices, column.Index)
 			if column.IsWriteIndex == "true" {
-				return column.Index
+				writeIndex = column.Index
 			}
 		}
 	}
 
-	return index
+	sort.Strings(indices)
+	return indices, writeIndex, nil
 }
 
 func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) error {
@@ -402,6 +2383,8 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 		index    = d.Id()
 		ctx      = context.Background()
 		settings map[string]interface{}
+		mappings map[string]interface{}
+		aliases  map[string]interface{}
 	)
 
 	if alias, ok := d.GetOk("rollover_alias"); ok {
@@ -413,6 +2396,11 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return err
 	}
+	if d.IsNewResource() && d.Get("wait_for_rollover_alias").(bool) {
+		if err := waitForIndexRolloverAlias(esClient, index, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
 		r, err := client.IndexGet(index).Do(ctx)
@@ -428,9 +2416,16 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 
 		if resp, ok := r[index]; ok {
 			settings = resp.Settings["index"].(map[string]interface{})
+			mappings = resp.Mappings
+			aliases = resp.Aliases
 		}
 	case *elastic6.Client:
-		r, err := client.IndexGet(index).Do(ctx)
+		service := client.IndexGet(index)
+		includeTypeName, includeTypeNameSet := d.GetOkExists("include_type_name")
+		if typed, param, err := indexIncludeTypeName(meta.(*ProviderConf).esVersion, includeTypeName, includeTypeNameSet); err == nil && param {
+			service = service.IncludeTypeName(typed)
+		}
+		r, err := service.Do(ctx)
 		if err != nil {
 			if elastic6.IsNotFound(err) {
 				log.Printf("[WARN] Index (%s) not found, removing from state", index)
@@ -442,6 +2437,8 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 
 		if resp, ok := r[index]; ok {
 			settings = resp.Settings["index"].(map[string]interface{})
+			mappings = resp.Mappings
+			aliases = resp.Aliases
 		}
 	default:
 		elastic5Client := client.(*elastic5.Client)
@@ -457,11 +2454,38 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 
 		if resp, ok := r[index]; ok {
 			settings = resp.Settings["index"].(map[string]interface{})
+			mappings = resp.Mappings
+			aliases = resp.Aliases
+		}
+	}
+
+	// Only read the aliases of the index itself rather than of the write index
+	// of its rollover alias, all of them on import, and otherwise only the
+	// configured ones, as aliases changed outside of this resource would force
+	// a replacement
+	_, managedAliases := d.GetOk("aliases")
+	_, named := d.GetOk("name")
+	if index == d.Id() && (managedAliases || !named) {
+		if managedAliases {
+			aliases = configuredIndexAliases(d.Get("aliases").(string), aliases)
+		}
+		aliasesJSON := ""
+		if len(aliases) > 0 {
+			preserveIndexAliasesWriteFlag(d.Get("aliases").(string), aliases)
+			bytes, err := json.Marshal(aliases)
+			if err != nil {
+				return err
+			}
+			aliasesJSON = string(bytes)
+		}
+		err := d.Set("aliases", aliasesJSON)
+		if err != nil {
+			return err
 		}
 	}
 
 	// Don't override name otherwise it will force a replacement
-	if _, ok := d.GetOk("name"); !ok {
+	if !named {
 		name := index
 		if providedName, ok := settings["provided_name"].(string); ok {
 			name = providedName
@@ -470,27 +2494,47 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 		if err != nil {
 			return err
 		}
-	}
 
-	// If index is managed by ILM or ISM set rollover_alias
-	if lifecycle, ok := settings["lifecycle"].(map[string]interface{}); ok {
-		if alias, ok := lifecycle["rollover_alias"].(string); ok {
-			err := d.Set("rollover_alias", alias)
+		// Only read the mappings on import, as documents with new fields
+		// extend them, which would force a replacement
+		if len(normalizeIndexMappings(mappings)) > 0 {
+			mappingsJSON, err := json.Marshal(mappings)
 			if err != nil {
-				log.Printf("[INFO] resourceElasticsearchIndexRead: %+v", err)
+				return err
 			}
-		}
-	} else if opendistro, ok := settings["opendistro"].(map[string]interface{}); ok {
-		if ism, ok := opendistro["index_state_management"].(map[string]interface{}); ok {
-			if alias, ok := ism["rollover_alias"].(string); ok {
-				err := d.Set("rollover_alias", alias)
-				if err != nil {
-					log.Printf("[INFO] resourceElasticsearchIndexRead: %+v", err)
-				}
+			err = d.Set("mappings", string(mappingsJSON))
+			if err != nil {
+				return err
 			}
 		}
 	}
 
+	// If index is managed by ILM or ISM set rollover_alias
+	if alias, ok := indexRolloverAlias(settings); ok {
+		err := d.Set("rollover_alias", alias)
+		if err != nil {
+			log.Printf("[INFO] resourceElasticsearchIndexRead: %+v", err)
+		}
+	}
+
+	ds := &resourceDataSetter{d: d}
+	if uuid, ok := settings["uuid"].(string); ok {
+		ds.set("uuid", uuid)
+	}
+	if creationDate, ok := settings["creation_date"].(string); ok {
+		if millis, err := strconv.ParseInt(creationDate, 10, 64); err == nil {
+			ds.set("creation_date", time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+		}
+	}
+	if indexVersion, ok := settings["version"].(map[string]interface{}); ok {
+		if created, ok := indexVersion["created"].(string); ok {
+			ds.set("version_created", created)
+		}
+	}
+	if ds.err != nil {
+		log.Printf("[INFO] resourceElasticsearchIndexRead: %+v", ds.err)
+	}
+
 	indexResourceDataFromSettings(settings, d)
 
 	return nil
@@ -0,0 +1,173 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchIndexState() *schema.Resource {
+	return &schema.Resource{
+		Description: "Opens or closes an existing index, e.g. one managed outside of Terraform. The index is neither created nor deleted, destroying the resource leaves the index in its current state.",
+		Create:      resourceElasticsearchIndexStateCreate,
+		Read:        resourceElasticsearchIndexStateRead,
+		Update:      resourceElasticsearchIndexStateUpdate,
+		Delete:      resourceElasticsearchIndexStateDelete,
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index",
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"open", "closed"}, false),
+				Description:  "Whether the index is `open` or `closed`",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchIndexStateCreate(d *schema.ResourceData, meta interface{}) error {
+	index := d.Get("index").(string)
+	if err := resourceElasticsearchPutIndexState(index, d.Get("state").(string), meta); err != nil {
+		return err
+	}
+	d.SetId(index)
+	return resourceElasticsearchIndexStateRead(d, meta)
+}
+
+func resourceElasticsearchIndexStateRead(d *schema.ResourceData, meta interface{}) error {
+	var (
+		index  = d.Id()
+		ctx    = context.Background()
+		status string
+	)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var rows elastic7.CatIndicesResponse
+		rows, err = client.CatIndices().Index(index).Columns("index", "status").Do(ctx)
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Index (%s) not found, removing from state", index)
+			d.SetId("")
+			return nil
+		}
+		for _, row := range rows {
+			if row.Index == index {
+				status = row.Status
+			}
+		}
+	case *elastic6.Client:
+		var rows elastic6.CatIndicesResponse
+		rows, err = client.CatIndices().Index(index).Columns("index", "status").Do(ctx)
+		if elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Index (%s) not found, removing from state", index)
+			d.SetId("")
+			return nil
+		}
+		for _, row := range rows {
+			if row.Index == index {
+				status = row.Status
+			}
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var rows elastic5.CatIndicesResponse
+		rows, err = elastic5Client.CatIndices().Index(index).Columns("index", "status").Do(ctx)
+		if elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Index (%s) not found, removing from state", index)
+			d.SetId("")
+			return nil
+		}
+		for _, row := range rows {
+			if row.Index == index {
+				status = row.Status
+			}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	if status == "" {
+		return fmt.Errorf("index %q not found in the cat indices response", index)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("index", index)
+	ds.set("state", indexStateFromStatus(status))
+	return ds.err
+}
+
+// indexStateFromStatus converts the status of an index in the cat indices
+// response, where a closed index is `close`, to the state attribute
+func indexStateFromStatus(status string) string {
+	if status == "close" {
+		return "closed"
+	}
+	return status
+}
+
+func resourceElasticsearchIndexStateUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutIndexState(d.Id(), d.Get("state").(string), meta); err != nil {
+		return err
+	}
+	return resourceElasticsearchIndexStateRead(d, meta)
+}
+
+func resourceElasticsearchIndexStateDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchPutIndexState(index, state string, meta interface{}) error {
+	var (
+		ctx           = context.Background()
+		masterTimeout = meta.(*ProviderConf).masterTimeout
+	)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		if state == "open" {
+			_, err = client.OpenIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		} else {
+			_, err = client.CloseIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		}
+	case *elastic6.Client:
+		if state == "open" {
+			_, err = client.OpenIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		} else {
+			_, err = client.CloseIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		if state == "open" {
+			_, err = elastic5Client.OpenIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		} else {
+			_, err = elastic5Client.CloseIndex(index).MasterTimeout(masterTimeout).Do(ctx)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,130 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func TestAccElasticsearchIndexState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIndexStateDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the index is managed outside of terraform
+				PreConfig: func() {
+					if err := testAccCreateIndex("terraform-test-state"); err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(testAccElasticsearchIndexState, "closed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index_state.test", "state", "closed"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexState, "open"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index_state.test", "state", "open"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index_state.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestIndexStateFromStatus(t *testing.T) {
+	for status, expected := range map[string]string{
+		"open":   "open",
+		"close":  "closed",
+		"closed": "closed",
+	} {
+		if state := indexStateFromStatus(status); state != expected {
+			t.Errorf("expected state %s for status %s, got %s", expected, status, state)
+		}
+	}
+}
+
+func testAccCreateIndex(name string) error {
+	meta := testAccProvider.Meta()
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.CreateIndex(name).Do(context.TODO())
+	case *elastic6.Client:
+		_, err = client.CreateIndex(name).Do(context.TODO())
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		_, err = elastic5Client.CreateIndex(name).Do(context.TODO())
+	}
+	return err
+}
+
+// testCheckElasticsearchIndexStateDestroy checks that the index was left in
+// place, and then deletes it
+func testCheckElasticsearchIndexStateDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_state" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		var exists bool
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			exists, err = client.IndexExists(rs.Primary.ID).Do(context.TODO())
+			if err == nil && exists {
+				_, err = client.DeleteIndex(rs.Primary.ID).Do(context.TODO())
+			}
+		case *elastic6.Client:
+			exists, err = client.IndexExists(rs.Primary.ID).Do(context.TODO())
+			if err == nil && exists {
+				_, err = client.DeleteIndex(rs.Primary.ID).Do(context.TODO())
+			}
+		default:
+			elastic5Client := client.(*elastic5.Client)
+			exists, err = elastic5Client.IndexExists(rs.Primary.ID).Do(context.TODO())
+			if err == nil && exists {
+				_, err = elastic5Client.DeleteIndex(rs.Primary.ID).Do(context.TODO())
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Index %q was deleted with its state", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccElasticsearchIndexState = `
+resource "elasticsearch_index_state" "test" {
+  index = "terraform-test-state"
+  state = "%s"
+}
+`
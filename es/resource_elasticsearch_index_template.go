@@ -137,12 +137,12 @@ func resourceElasticsearchIndexTemplateDelete(d *schema.ResourceData, meta inter
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		err = elastic7IndexDeleteTemplate(client, id)
+		err = elastic7IndexDeleteTemplate(client, id, meta.(*ProviderConf).masterTimeout)
 	case *elastic6.Client:
-		err = elastic6IndexDeleteTemplate(client, id)
+		err = elastic6IndexDeleteTemplate(client, id, meta.(*ProviderConf).masterTimeout)
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		err = elastic5IndexDeleteTemplate(elastic5Client, id)
+		err = elastic5IndexDeleteTemplate(elastic5Client, id, meta.(*ProviderConf).masterTimeout)
 	}
 
 	if err != nil {
@@ -152,18 +152,18 @@ func resourceElasticsearchIndexTemplateDelete(d *schema.ResourceData, meta inter
 	return nil
 }
 
-func elastic7IndexDeleteTemplate(client *elastic7.Client, id string) error {
-	_, err := client.IndexDeleteTemplate(id).Do(context.TODO())
+func elastic7IndexDeleteTemplate(client *elastic7.Client, id string, masterTimeout string) error {
+	_, err := client.IndexDeleteTemplate(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic6IndexDeleteTemplate(client *elastic6.Client, id string) error {
-	_, err := client.IndexDeleteTemplate(id).Do(context.TODO())
+func elastic6IndexDeleteTemplate(client *elastic6.Client, id string, masterTimeout string) error {
+	_, err := client.IndexDeleteTemplate(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic5IndexDeleteTemplate(client *elastic5.Client, id string) error {
-	_, err := client.IndexDeleteTemplate(id).Do(context.TODO())
+func elastic5IndexDeleteTemplate(client *elastic5.Client, id string, masterTimeout string) error {
+	_, err := client.IndexDeleteTemplate(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
@@ -178,28 +178,28 @@ func resourceElasticsearchPutIndexTemplate(d *schema.ResourceData, meta interfac
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		err = elastic7IndexPutTemplate(client, name, body, create)
+		err = elastic7IndexPutTemplate(client, name, body, create, meta.(*ProviderConf).masterTimeout)
 	case *elastic6.Client:
-		err = elastic6IndexPutTemplate(client, name, body, create)
+		err = elastic6IndexPutTemplate(client, name, body, create, meta.(*ProviderConf).masterTimeout)
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		err = elastic5IndexPutTemplate(elastic5Client, name, body, create)
+		err = elastic5IndexPutTemplate(elastic5Client, name, body, create, meta.(*ProviderConf).masterTimeout)
 	}
 
 	return err
 }
 
-func elastic7IndexPutTemplate(client *elastic7.Client, name string, body string, create bool) error {
-	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).Do(context.TODO())
+func elastic7IndexPutTemplate(client *elastic7.Client, name string, body string, create bool, masterTimeout string) error {
+	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic6IndexPutTemplate(client *elastic6.Client, name string, body string, create bool) error {
-	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).Do(context.TODO())
+func elastic6IndexPutTemplate(client *elastic6.Client, name string, body string, create bool, masterTimeout string) error {
+	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic5IndexPutTemplate(client *elastic5.Client, name string, body string, create bool) error {
-	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).Do(context.TODO())
+func elastic5IndexPutTemplate(client *elastic5.Client, name string, body string, create bool, masterTimeout string) error {
+	_, err := client.IndexPutTemplate(name).BodyString(body).Create(create).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
@@ -60,127 +65,2309 @@ resource "elasticsearch_index" "test" {
   force_destroy = true
 }
 `
-	testAccElasticsearchIndexDateMath = `
-resource "elasticsearch_index" "test_date_math" {
-  name = "<terraform-test-{now/y{yyyy}}-000001>"
-  # name = "%3Ctest-%7Bnow%2Fy%7Byyyy%7D%7D-000001%3E"
+	testAccElasticsearchIndexRoutingAllocation = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
   number_of_shards = 1
   number_of_replicas = 1
+  routing_allocation = {
+    "exclude._name" = "terraform-test-node"
+  }
 }
 `
-	testAccElasticsearchIndexRolloverAliasXpack = `
-resource "elasticsearch_index_lifecycle_policy" "test" {
+	testAccElasticsearchIndexMappingLimits = `
+resource "elasticsearch_index" "test" {
   name = "terraform-test"
-  body = <<EOF
+  number_of_shards = 1
+  number_of_replicas = 1
+  mapping_total_fields_limit = 2000
+  mapping_depth_limit = 10
+  mapping_nested_fields_limit = 20
+}
+`
+	testAccElasticsearchIndexIgnoreSettings = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  refresh_interval = "5s"
+  ignore_settings = ["index.refresh_interval"]
+}
+`
+	testAccElasticsearchIndexIgnoreSettingsUpdate = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  refresh_interval = "10s"
+  ignore_settings = ["index.refresh_interval"]
+}
+`
+	testAccElasticsearchIndexSearchIdleAfter = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  search_idle_after = "60s"
+}
+`
+	testAccElasticsearchIndexSearchIdleAfterInvalid = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  search_idle_after = "60 seconds"
+}
+`
+	testAccElasticsearchIndexAutoExpandReplicas = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  auto_expand_replicas = "0-all"
+}
+`
+	testAccElasticsearchIndexDelayedTimeout = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  delayed_timeout = "5m"
+}
+`
+	testAccElasticsearchIndexTranslog = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  translog_durability = "async"
+  translog_sync_interval = "10s"
+  translog_flush_threshold_size = "256mb"
+}
+`
+	testAccElasticsearchIndexTranslogInvalid = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  translog_durability = "never"
+}
+`
+	testAccElasticsearchIndexStorePreload = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  store_preload = ["nvd", "dvd"]
+}
+`
+	testAccElasticsearchIndexSlowlog = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  search_slowlog_threshold_query_warn = "10s"
+  search_slowlog_threshold_fetch_info = "800ms"
+  indexing_slowlog_threshold_index_debug = "2s"
+  indexing_slowlog_source = "500"
+}
+`
+	testAccElasticsearchIndexSlowlogInvalid = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  search_slowlog_threshold_query_warn = "10 seconds"
+}
+`
+	testAccElasticsearchIndexTypedMappings = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings = <<EOF
 {
-  "policy": {
-    "phases": {
-      "hot": {
-        "min_age": "0ms",
-        "actions": {
-          "rollover": {
-            "max_size": "50gb"
-          }
-        }
+  "doc": {
+    "properties": {
+      "email": {
+        "type": "keyword"
       }
     }
   }
 }
 EOF
 }
-
-resource "elasticsearch_index_template" "test" {
+`
+	testAccElasticsearchIndexTypelessMappings = `
+resource "elasticsearch_index" "test" {
   name = "terraform-test"
-  body = <<EOF
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings = <<EOF
 {
-  "index_patterns": ["terraform-test-*"],
-  "settings": {
-    "index": {
-      "lifecycle": {
-        "name": "${elasticsearch_index_lifecycle_policy.test.name}",
-        "rollover_alias": "terraform-test"
-      }
+  "properties": {
+    "email": {
+      "type": "keyword"
     }
   }
 }
 EOF
 }
-
+`
+	testAccElasticsearchIndexAliases = `
 resource "elasticsearch_index" "test" {
-  name = "terraform-test-000001"
+  name = "terraform-test"
   number_of_shards = 1
   number_of_replicas = 1
   aliases = jsonencode({
-    "terraform-test" = {
-      "is_write_index" = true
+    "terraform-test-alias" = {}
+    "terraform-test-filtered" = {
+      "routing" = "1"
+      "filter" = {
+        "term" = {
+          "user" = "terraform"
+        }
+      }
     }
   })
+}
+`
+	testAccElasticsearchIndexCodec = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  codec = "%s"
+}
+`
+	testAccElasticsearchIndexWaitForStatus = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 0
+  wait_for_status = "green"
+}
+`
+	testAccElasticsearchIndexWaitForStatusNone = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 0
+  wait_for_status = "none"
+  routing_allocation = {
+    "require._name" = "terraform-test-missing-node"
+  }
 
-  depends_on = [elasticsearch_index_template.test]
+  timeouts {
+    create = "10s"
+  }
 }
 `
-	testAccElasticsearchIndexRolloverAliasOpendistro = `
-resource elasticsearch_opendistro_ism_policy "test" {
-  policy_id = "test"
-  body      = <<EOF
+	testAccElasticsearchIndexGcDeletes = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  gc_deletes = "%s"
+}
+`
+	testAccElasticsearchIndexPipelines = `
+resource "elasticsearch_ingest_pipeline" "test" {
+  name = "terraform-test"
+  body = <<EOF
 {
-  "policy": {
-    "description": "Terraform Test",
-    "default_state": "hot",
-    "schema_version": 1,
-    "states": [
-      {
-        "name": "hot",
-        "actions": [
-          {
-            "rollover": {
-              "min_size": "50gb"
-            }
-          }
-        ],
-        "transitions": []
+  "description" : "describe pipeline",
+  "processors" : [
+    {
+      "set" : {
+        "field": "foo",
+        "value": "bar"
       }
-    ]
-  }
+    }
+  ]
 }
-  EOF
+EOF
 }
 
-resource "elasticsearch_index_template" "test" {
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  default_pipeline = elasticsearch_ingest_pipeline.test.name
+  final_pipeline = elasticsearch_ingest_pipeline.test.name
+}
+`
+	testAccElasticsearchIndexRemovedPipelines = `
+resource "elasticsearch_ingest_pipeline" "test" {
   name = "terraform-test"
   body = <<EOF
 {
-  "index_patterns": ["terraform-test-*"],
-  "settings": {
-    "index": {
-      "opendistro": {
-        "index_state_management": {
-          "policy_id": "${elasticsearch_opendistro_ism_policy.test.policy_id}",
-          "rollover_alias": "terraform-test"
-        }
+  "description" : "describe pipeline",
+  "processors" : [
+    {
+      "set" : {
+        "field": "foo",
+        "value": "bar"
       }
     }
-  }
+  ]
 }
 EOF
 }
 
 resource "elasticsearch_index" "test" {
-  name = "terraform-test-000001"
+  name = "terraform-test"
   number_of_shards = 1
   number_of_replicas = 1
-  aliases = jsonencode({
-    "terraform-test" = {
-      "is_write_index" = true
+}
+`
+	testAccElasticsearchIndexNoPipelines = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  default_pipeline = "_none"
+  final_pipeline = "_none"
+}
+`
+	testAccElasticsearchIndexNumberOfRoutingShards = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 2
+  number_of_routing_shards = 8
+  number_of_replicas = 1
+}
+`
+	testAccElasticsearchIndexMerge = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  merge_policy_max_merged_segment = "%s"
+  merge_policy_segments_per_tier = %d
+  merge_scheduler_max_thread_count = %d
+}
+`
+	testAccElasticsearchIndexRoutingEnable = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  routing_allocation_enable = "%s"
+  routing_rebalance_enable = "%s"
+}
+`
+	testAccElasticsearchIndexWriteWaitForActiveShards = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  write_wait_for_active_shards = "%s"
+}
+`
+	testAccElasticsearchIndexDocuments = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  force_destroy = true
+  documents_refresh = "true"
+  documents = jsonencode(%s)
+}
+`
+	testAccElasticsearchIndexMappingFieldType = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings = jsonencode({
+    properties = {
+      name = {
+        type = "keyword"
+        fields = {
+          text = { type = "%s" }
+        }
+      }
     }
   })
+}
+`
+	testAccElasticsearchIndexQueryStringLenient = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  query_string_lenient = %t
+}
+`
+	testAccElasticsearchIndexBlocksReadOnlyAllowDelete = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  %s
+}
+`
+	testAccElasticsearchIndexStoreType = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  store_type = "%s"
+}
+`
+	testAccElasticsearchIndexSearchLimitsBlock = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  search_limits {
+    max_result_window = %d
+    max_rescore_window = 5000
+    %s
+  }
+}
+`
+	testAccElasticsearchIndexSearchLimitsBlockConflict = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  max_terms_count = 1000
+  search_limits {
+    max_result_window = 20000
+  }
+}
+`
+	testAccElasticsearchIndexIndexingComplete = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  indexing_complete = %t
+}
+`
+	testAccElasticsearchIndexIncludeTypeName = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  include_type_name = %t
+  mappings = jsonencode({
+    properties = {
+      name = { type = "keyword" }
+    }
+  })
+}
+`
+	testAccElasticsearchIndexSoftDeletes = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  soft_deletes_enabled = %t
+  soft_deletes_retention_lease_period = "%s"
+  soft_deletes_retention_operations = %d
+}
+`
+	testAccElasticsearchIndexSort = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  sort_field = ["timestamp", "username"]
+  sort_order = ["desc", "asc"]
+  sort_missing = ["_last", "_first"]
+  mappings = jsonencode({
+    properties = {
+      timestamp = { type = "date" }
+      username  = { type = "keyword" }
+    }
+  })
+}
+`
+	testAccElasticsearchIndexValidateMapping = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  validate_mapping = true
+  mappings = jsonencode(%s)
+}
+`
+	testAccElasticsearchIndexAnalyzeMaxTokenCount = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  analyze_max_token_count = %d
+}
+`
+	testAccElasticsearchIndexSettingsJSON = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  settings = jsonencode(%s)
+}
+`
+	testAccElasticsearchIndexWriteAliasDuplicate = `
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test"
+  number_of_shards   = 1
+  number_of_replicas = 1
+  aliases            = <<EOF
+{
+  "terraform-test-write": { "is_write_index": true },
+  "terraform-test-write": { "is_write_index": true }
+}
+EOF
+}
+`
+	testAccElasticsearchIndexWriteAlias = `
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test"
+  number_of_shards   = 1
+  number_of_replicas = 1
+  aliases = jsonencode({
+    "terraform-test-write" = {
+      "is_write_index" = true
+    }
+  })
+}
+`
+	testAccElasticsearchIndexWriteAliasConflict = testAccElasticsearchIndexWriteAlias + `
+resource "elasticsearch_index" "test_other" {
+  name               = "terraform-test-other"
+  number_of_shards   = 1
+  number_of_replicas = 1
+  aliases = jsonencode({
+    "terraform-test-write" = {
+      "is_write_index" = true
+    }
+  })
+
+  depends_on = [elasticsearch_index.test]
+}
+`
+	testAccElasticsearchIndexBoolSettings = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  load_fixed_bitset_filters_eagerly = false
+  query_string_lenient = true
+}
+`
+	testAccElasticsearchIndexRoutingPartitionSize = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 4
+  number_of_replicas = 1
+  routing_partition_size = 2
+  mapping_total_fields_limit = 2000
+  mappings = <<EOF
+{
+  "_routing": {
+    "required": true
+  }
+}
+EOF
+}
+`
+	testAccElasticsearchIndexRoutingPartitionSizeInvalid = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = %d
+  number_of_replicas = 1
+  routing_partition_size = 2
+  %s
+}
+`
+	testAccElasticsearchIndexJSONFilesConflict = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings = jsonencode({ properties = {} })
+  mappings_file = "%s"
+}
+`
+	testAccElasticsearchIndexJSONFiles = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings_file = "%s"
+  aliases_file = "%s"
+  settings_file = "%s"
+}
+`
+	testAccElasticsearchIndexPriority = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  priority = %d
+}
+`
+	testAccElasticsearchIndexNoReplicas = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 0
+}
+`
+	testAccElasticsearchIndexRecreate = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 2
+  number_of_replicas = 2
+}
+`
+	testAccElasticsearchIndexMappingCoerce = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mapping_ignore_malformed = true
+  mapping_coerce = %t
+}
+`
+	testAccElasticsearchIndexWaitForDeletion = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  wait_for_deletion = true
+}
+`
+	testAccElasticsearchIndexAdoptExisting = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  adopt_existing = %t
+}
+`
+	testAccElasticsearchIndexSearchLimits = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  max_docvalue_fields_search = 50
+  highlight_max_analyzed_offset = 500000
+  max_terms_count = 1024
+  max_regex_length = 100
+  max_slices_per_scroll = 512
+}
+`
+	testAccElasticsearchIndexDateMath = `
+resource "elasticsearch_index" "test_date_math" {
+  name = "<terraform-test-{now/y{yyyy}}-000001>"
+  # name = "%3Ctest-%7Bnow%2Fy%7Byyyy%7D%7D-000001%3E"
+  number_of_shards = 1
+  number_of_replicas = 1
+}
+`
+	testAccElasticsearchIndexRolloverAliasXpack = `
+resource "elasticsearch_index_lifecycle_policy" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "policy": {
+    "phases": {
+      "hot": {
+        "min_age": "0ms",
+        "actions": {
+          "rollover": {
+            "max_size": "50gb"
+          }
+        }
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-*"],
+  "settings": {
+    "index": {
+      "lifecycle": {
+        "name": "${elasticsearch_index_lifecycle_policy.test.name}",
+        "rollover_alias": "terraform-test"
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-000001"
+  number_of_shards = 1
+  number_of_replicas = 1
+  aliases = jsonencode({
+    "terraform-test" = {
+      "is_write_index" = true
+    }
+  })
+
+  depends_on = [elasticsearch_index_template.test]
+}
+`
+	testAccElasticsearchIndexRolloverAliasOpendistro = `
+resource elasticsearch_opendistro_ism_policy "test" {
+  policy_id = "test"
+  body      = <<EOF
+{
+  "policy": {
+    "description": "Terraform Test",
+    "default_state": "hot",
+    "schema_version": 1,
+    "states": [
+      {
+        "name": "hot",
+        "actions": [
+          {
+            "rollover": {
+              "min_size": "50gb"
+            }
+          }
+        ],
+        "transitions": []
+      }
+    ]
+  }
+}
+  EOF
+}
+
+resource "elasticsearch_index_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-*"],
+  "settings": {
+    "index": {
+      "opendistro": {
+        "index_state_management": {
+          "policy_id": "${elasticsearch_opendistro_ism_policy.test.policy_id}",
+          "rollover_alias": "terraform-test"
+        }
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-000001"
+  number_of_shards = 1
+  number_of_replicas = 1
+  aliases = jsonencode({
+    "terraform-test" = {
+      "is_write_index" = true
+    }
+  })
+
+  depends_on = [elasticsearch_index_template.test]
+}
+`
+)
+
+func TestAccElasticsearchIndex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					checkElasticsearchIndexSettingAttr("elasticsearch_index.test", "uuid", "uuid"),
+					checkElasticsearchIndexSettingAttr("elasticsearch_index.test", "version.created", "version_created"),
+					resource.TestMatchResourceAttr("elasticsearch_index.test", "creation_date", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexUpdate1,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUpdated("elasticsearch_index.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexUpdateForceDestroy,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUpdated("elasticsearch_index.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_handleInvalid(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Only tested on ES >= 6")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexInvalid,
+				ExpectError: regexp.MustCompile("Failed to parse mapping"),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndex,
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					// not returned from the API
+					"force_destroy",
+					"wait_for_deletion",
+					"adopt_existing",
+					"wait_for_status",
+					"documents_refresh",
+					"wait_for_rollover_alias",
+				},
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_routingAllocation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexRoutingAllocation,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "routing_allocation.exclude._name", "terraform-test-node"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "routing_allocation.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_mappingLimits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexMappingLimits,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_total_fields_limit", "2000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_depth_limit", "10"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_nested_fields_limit", "20"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_total_fields_limit", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_ignoreSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexIgnoreSettings,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "refresh_interval", "5s"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexIgnoreSettingsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "refresh_interval", "5s"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_searchIdleAfter(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic6.Client, *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Search idle only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexSearchIdleAfterInvalid,
+				ExpectError: regexp.MustCompile("must be a time value"),
+			},
+			{
+				Config: testAccElasticsearchIndexSearchIdleAfter,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "search.idle.after", "60s"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_idle_after", "60s"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_autoExpandReplicas(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexAutoExpandReplicas,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "auto_expand_replicas", "0-all"),
+				),
+			},
+			{
+				// the computed replicas on a single node cluster must not cause a diff
+				Config:   testAccElasticsearchIndexAutoExpandReplicas,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_delayedTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexDelayedTimeout,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "unassigned.node_left.delayed_timeout", "5m"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "delayed_timeout", "5m"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "delayed_timeout", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_translog(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexTranslogInvalid,
+				ExpectError: regexp.MustCompile("expected translog_durability to be one of"),
+			},
+			{
+				Config: testAccElasticsearchIndexTranslog,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "translog.durability", "async"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "translog.sync_interval", "10s"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "translog.flush_threshold_size", "256mb"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "translog_durability", "async"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "translog_sync_interval", "10s"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "translog_flush_threshold_size", "256mb"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_gcDeletes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexGcDeletes, "1 minute"),
+				ExpectError: regexp.MustCompile("must be a time value"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexGcDeletes, "120s"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "gc_deletes", "120s"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "gc_deletes", "120s"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexGcDeletes, "5m"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "gc_deletes", "5m"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "gc_deletes", "5m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_waitForStatus(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexWaitForStatus,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "wait_for_status", "green"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_waitForStatusNone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the shards can't be allocated, so the index stays red and
+				// waiting for it would time out
+				Config: testAccElasticsearchIndexWaitForStatusNone,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "wait_for_status", "none"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_codec(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var zstdAllowed bool
+	if client, ok := esClient.(*elastic7.Client); ok {
+		elasticVersion, err := elastic7GetVersion(client)
+		zstdAllowed = err == nil && !elasticVersion.LessThan(zstdCodecMinimalVersion)
+	}
+
+	steps := []resource.TestStep{
+		{
+			Config:      fmt.Sprintf(testAccElasticsearchIndexCodec, "lz4"),
+			ExpectError: regexp.MustCompile("expected codec to be one of"),
+		},
+	}
+	for _, codec := range indexCodecs {
+		step := resource.TestStep{
+			Config: fmt.Sprintf(testAccElasticsearchIndexCodec, codec),
+			Check: resource.ComposeTestCheckFunc(
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "codec", codec),
+				resource.TestCheckResourceAttr("elasticsearch_index.test", "codec", codec),
+			),
+		}
+		if strings.Contains(codec, "zstd") && !zstdAllowed {
+			step.Check = nil
+			step.ExpectError = regexp.MustCompile("only supported from ElasticSearch >= 8.0")
+		}
+		steps = append(steps, step)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps:        steps,
+	})
+}
+
+func TestAccElasticsearchIndex_mappingFieldTypes(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var matchOnlyTextAllowed bool
+	if client, ok := esClient.(*elastic7.Client); ok {
+		elasticVersion, err := elastic7GetVersion(client)
+		minimalVersion, _ := version.NewVersion(mappingFieldTypeMinimalVersions["match_only_text"])
+		matchOnlyTextAllowed = err == nil && !elasticVersion.LessThan(minimalVersion)
+	}
+
+	step := resource.TestStep{
+		Config: fmt.Sprintf(testAccElasticsearchIndexMappingFieldType, "match_only_text"),
+		Check: resource.ComposeTestCheckFunc(
+			checkElasticsearchIndexExists("elasticsearch_index.test"),
+		),
+	}
+	if !matchOnlyTextAllowed {
+		step.Check = nil
+		step.ExpectError = regexp.MustCompile(`mappings field type "match_only_text" is only supported from ElasticSearch >= 7.14`)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps:        []resource.TestStep{step},
+	})
+}
+
+var finalPipelineMinimalVersion, _ = version.NewVersion("7.5.0")
+
+func TestAccElasticsearchIndex_pipelines(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	if client, ok := esClient.(*elastic7.Client); ok {
+		elasticVersion, err := elastic7GetVersion(client)
+		allowed = err == nil && !elasticVersion.LessThan(finalPipelineMinimalVersion)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Final pipelines only supported on ES >= 7.5")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexPipelines,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "default_pipeline", "terraform-test"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "final_pipeline", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "default_pipeline", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "final_pipeline", "terraform-test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexNoPipelines,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "default_pipeline", "_none"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "final_pipeline", "_none"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "default_pipeline", "_none"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "final_pipeline", "_none"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexPipelines,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "default_pipeline", "terraform-test"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "final_pipeline", "terraform-test"),
+				),
+			},
+			{
+				// removing the pipelines detaches them, which reads back as unset
+				Config: testAccElasticsearchIndexRemovedPipelines,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "default_pipeline", "_none"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "final_pipeline", "_none"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "default_pipeline", ""),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "final_pipeline", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_numberOfRoutingShards(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("number_of_routing_shards only supported on ES >= 6.1")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexNumberOfRoutingShards,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "number_of_routing_shards", "8"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "number_of_routing_shards", "8"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+				},
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_merge(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexMerge, "5 gigabytes", 10, 1),
+				ExpectError: regexp.MustCompile("must be a byte size value"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexMerge, "5gb", 10, 1),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.policy.max_merged_segment", "5gb"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.policy.segments_per_tier", "10"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.scheduler.max_thread_count", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "merge_policy_max_merged_segment", "5gb"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "merge_policy_segments_per_tier", "10"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "merge_scheduler_max_thread_count", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexMerge, "2gb", 5, 2),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.policy.max_merged_segment", "2gb"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.policy.segments_per_tier", "5"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "merge.scheduler.max_thread_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_routingEnable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexRoutingEnable, "replicas", "all"),
+				ExpectError: regexp.MustCompile("expected routing_allocation_enable to be one of"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexRoutingEnable, "primaries", "none"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "routing.allocation.enable", "primaries"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "routing.rebalance.enable", "none"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "routing_allocation_enable", "primaries"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "routing_rebalance_enable", "none"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexRoutingEnable, "all", "all"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "routing.allocation.enable", "all"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "routing.rebalance.enable", "all"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_writeWaitForActiveShards(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexWriteWaitForActiveShards, "some"),
+				ExpectError: regexp.MustCompile("must be `all` or a number"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexWriteWaitForActiveShards, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "write.wait_for_active_shards", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "write_wait_for_active_shards", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexWriteWaitForActiveShards, "all"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "write.wait_for_active_shards", "all"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "write_wait_for_active_shards", "all"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_documents(t *testing.T) {
+	var uuid string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexDocuments, `{ name = "one" }`),
+				ExpectError: regexp.MustCompile("must be a JSON array of objects"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexDocuments, `[{ "_id" = "1", name = "one" }, { "_id" = "2", name = "two" }]`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexDocumentCount("elasticsearch_index.test", 2),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexDocuments, `[{ "_id" = "1", name = "one" }, { "_id" = "2", name = "two" }, { "_id" = "3", name = "three" }]`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexDocumentCount("elasticsearch_index.test", 3),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_queryStringLenient(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexQueryStringLenient, true),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "query_string.lenient", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "query_string_lenient", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexQueryStringLenient, false),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "query_string.lenient", "false"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "query_string_lenient", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_blocksReadOnlyAllowDelete(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	esClient, err := getClient(provider.Meta().(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	// block the index as ES does when the flood stage disk watermark is exceeded
+	block := func() {
+		body := `{"index.blocks.read_only_allow_delete": true}`
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			_, err = client.IndexPutSettings("terraform-test").BodyString(body).Do(context.TODO())
+		case *elastic6.Client:
+			_, err = client.IndexPutSettings("terraform-test").BodyString(body).Do(context.TODO())
+		default:
+			elastic5Client := client.(*elastic5.Client)
+			_, err = elastic5Client.IndexPutSettings("terraform-test").BodyString(body).Do(context.TODO())
+		}
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexBlocksReadOnlyAllowDelete, "blocks_read_only_allow_delete = false"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "blocks.read_only_allow_delete", "false"),
+				),
+			},
+			{
+				PreConfig: block,
+				Config:    fmt.Sprintf(testAccElasticsearchIndexBlocksReadOnlyAllowDelete, "blocks_read_only_allow_delete = false"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "blocks.read_only_allow_delete", "false"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "blocks_read_only_allow_delete", "false"),
+				),
+			},
+			{
+				PreConfig: block,
+				Config:    fmt.Sprintf(testAccElasticsearchIndexBlocksReadOnlyAllowDelete, ""),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "blocks.read_only_allow_delete", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_storeType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexStoreType, "memory"),
+				ExpectError: regexp.MustCompile("expected store_type to be one of"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexStoreType, "niofs"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "store.type", "niofs"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "store_type", "niofs"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexStoreType, "mmapfs"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "store.type", "mmapfs"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "store_type", "mmapfs"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_searchLimitsBlock(t *testing.T) {
+	var uuid string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexSearchLimitsBlockConflict,
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexSearchLimitsBlock, 20000, "max_terms_count = 1000"),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_result_window", "20000"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_rescore_window", "5000"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_terms_count", "1000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_limits.0.max_result_window", "20000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_limits.0.max_terms_count", "1000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "max_terms_count", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexSearchLimitsBlock, 30000, ""),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_result_window", "30000"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_terms_count", ""),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_limits.0.max_result_window", "30000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_limits.0.max_terms_count", "0"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexSearchLimits,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_result_window", ""),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_limits.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_indexingComplete(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Index lifecycles only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: checkElasticsearchIndexDestroyWithProvider(testAccXPackProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexIndexingComplete, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "indexing_complete", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexIndexingComplete, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "indexing_complete", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_includeTypeName(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	conf := provider.Meta().(*ProviderConf)
+	if _, err := getClient(conf); err != nil {
+		t.Skipf("err: %s", err)
+	}
+	elasticVersion, err := version.NewVersion(conf.esVersion)
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	// Use the layout opposite to the default one of the cluster
+	includeTypeName := !elasticVersion.LessThan(typelessMappingsMinimalVersion)
+	step := resource.TestStep{
+		Config: fmt.Sprintf(testAccElasticsearchIndexIncludeTypeName, includeTypeName),
+		Check: resource.ComposeTestCheckFunc(
+			checkElasticsearchIndexExists("elasticsearch_index.test"),
+			resource.TestCheckResourceAttr("elasticsearch_index.test", "include_type_name", fmt.Sprint(includeTypeName)),
+		),
+	}
+	if elasticVersion.LessThan(includeTypeNameMinimalVersion) || !elasticVersion.LessThan(includeTypeNameMaximalVersion) {
+		step.Check = nil
+		step.ExpectError = regexp.MustCompile(`include_type_name is only supported from ElasticSearch >= 6.7.0 and < 8.0.0`)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps:        []resource.TestStep{step},
+	})
+}
+
+var (
+	softDeletesRetentionLeaseMinimalVersion, _ = version.NewVersion("6.7.0")
+	softDeletesDisabledMaximalVersion, _       = version.NewVersion("8.0.0")
+)
+
+func TestAccElasticsearchIndex_softDeletes(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	conf := provider.Meta().(*ProviderConf)
+	if _, err := getClient(conf); err != nil {
+		t.Skipf("err: %s", err)
+	}
+	elasticVersion, err := version.NewVersion(conf.esVersion)
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if elasticVersion.LessThan(softDeletesRetentionLeaseMinimalVersion) {
+		t.Skipf("soft_deletes_retention_lease_period is only supported from ElasticSearch >= 6.7, got version %s", elasticVersion.String())
+	}
+
+	var uuid string
+	steps := []resource.TestStep{
+		{
+			Config:      fmt.Sprintf(testAccElasticsearchIndexSoftDeletes, true, "12 hours", 0),
+			ExpectError: regexp.MustCompile("must be a time value"),
+		},
+		{
+			Config: fmt.Sprintf(testAccElasticsearchIndexSoftDeletes, true, "12h", 0),
+			Check: resource.ComposeTestCheckFunc(
+				checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.enabled", "true"),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.retention_lease.period", "12h"),
+				resource.TestCheckResourceAttr("elasticsearch_index.test", "soft_deletes_enabled", "true"),
+				resource.TestCheckResourceAttr("elasticsearch_index.test", "soft_deletes_retention_lease_period", "12h"),
+			),
+		},
+		{
+			// the retention settings are dynamic, the index is updated in place
+			Config: fmt.Sprintf(testAccElasticsearchIndexSoftDeletes, true, "1d", 100),
+			Check: resource.ComposeTestCheckFunc(
+				checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.retention_lease.period", "1d"),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.retention.operations", "100"),
+				resource.TestCheckResourceAttr("elasticsearch_index.test", "soft_deletes_retention_lease_period", "1d"),
+				resource.TestCheckResourceAttr("elasticsearch_index.test", "soft_deletes_retention_operations", "100"),
+			),
+		},
+	}
+	// soft deletes can't be disabled from ES 8
+	if elasticVersion.LessThan(softDeletesDisabledMaximalVersion) {
+		steps = append(steps, resource.TestStep{
+			// soft_deletes_enabled is static, the index is recreated
+			Config: fmt.Sprintf(testAccElasticsearchIndexSoftDeletes, false, "1d", 100),
+			Check: resource.ComposeTestCheckFunc(
+				checkElasticsearchIndexRecreated("elasticsearch_index.test", &uuid),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.enabled", "false"),
+				checkElasticsearchIndexSetting("elasticsearch_index.test", "soft_deletes.retention.operations", "100"),
+			),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps:        steps,
+	})
+}
+
+func TestAccElasticsearchIndex_sort(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("index sorting is only supported from ElasticSearch >= 6.0")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexSort,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_field.#", "2"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_field.0", "timestamp"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_field.1", "username"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_order.0", "desc"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_order.1", "asc"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_missing.0", "_last"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "sort_missing.1", "_first"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_index.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"mappings", "force_destroy", "wait_for_deletion", "adopt_existing", "wait_for_status", "documents_refresh", "wait_for_rollover_alias"},
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_validateMapping(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexValidateMapping, `{ dynamic = "strict" }`),
+				ExpectError: regexp.MustCompile("mappings must define `properties`"),
+			},
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexValidateMapping, `{ properties = { name = { type = "not_a_type" } } }`),
+				ExpectError: regexp.MustCompile("mappings are invalid"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexValidateMapping, `{ properties = { name = { type = "keyword" } } }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "validate_mapping", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_analyzeMaxTokenCount(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("analyze_max_token_count is only supported from ElasticSearch >= 6.0")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexAnalyzeMaxTokenCount, 0),
+				ExpectError: regexp.MustCompile("expected analyze_max_token_count to be at least"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexAnalyzeMaxTokenCount, 20000),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "analyze.max_token_count", "20000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "analyze_max_token_count", "20000"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexAnalyzeMaxTokenCount, 50000),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "analyze.max_token_count", "50000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "analyze_max_token_count", "50000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_settingsJSON(t *testing.T) {
+	var uuid string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "index.number_of_replicas" = 2 }`),
+				ExpectError: regexp.MustCompile("is managed by the `number_of_replicas` attribute"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "index.requests.cache.enable" = false, "max_refresh_listeners" = 500 }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "requests.cache.enable", "false"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_refresh_listeners", "500"),
+				),
+			},
+			{
+				// the nested form of the same settings doesn't change anything
+				Config:   fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ index = { requests = { cache = { enable = false } }, max_refresh_listeners = "500" } }`),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "requests.cache.enable" = true }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "requests.cache.enable", "true"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_refresh_listeners", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_settingsJSONStaticDynamic(t *testing.T) {
+	var uuid string
+	analysis := `"analysis.analyzer.test.type" = "custom", "analysis.analyzer.test.tokenizer" = "%s"`
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "requests.cache.enable" = false, `+fmt.Sprintf(analysis, "standard")+` }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "analysis.analyzer.test.tokenizer", "standard"),
+				),
+			},
+			{
+				// only a dynamic setting changes, the index is updated in place
+				Config: fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "requests.cache.enable" = true, `+fmt.Sprintf(analysis, "standard")+` }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "requests.cache.enable", "true"),
+				),
+			},
+			{
+				// a static setting changes along with a dynamic one, the index is recreated
+				Config: fmt.Sprintf(testAccElasticsearchIndexSettingsJSON, `{ "requests.cache.enable" = false, `+fmt.Sprintf(analysis, "whitespace")+` }`),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexRecreated("elasticsearch_index.test", &uuid),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "requests.cache.enable", "false"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "analysis.analyzer.test.tokenizer", "whitespace"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_writeAlias(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	conf := provider.Meta().(*ProviderConf)
+	if _, err := getClient(conf); err != nil {
+		t.Skipf("err: %s", err)
+	}
+	elasticVersion, err := version.NewVersion(conf.esVersion)
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if elasticVersion.LessThan(aliasIsWriteIndexMinimalVersion) {
+		t.Skipf("is_write_index is only supported from ElasticSearch >= 6.4, got version %s", elasticVersion.String())
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexWriteAliasDuplicate,
+				ExpectError: regexp.MustCompile(`alias "terraform-test-write" is defined more than once`),
+			},
+			{
+				Config: testAccElasticsearchIndexWriteAlias,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestMatchResourceAttr("elasticsearch_index.test", "aliases", regexp.MustCompile(`"is_write_index":true`)),
+				),
+			},
+			{
+				Config:      testAccElasticsearchIndexWriteAliasConflict,
+				ExpectError: regexp.MustCompile("alias terraform-test-write already has the write index terraform-test"),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_importBoolSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexBoolSettings,
+				Check: resource.ComposeTestCheckFunc(
+					// ES returns boolean settings as strings
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "load_fixed_bitset_filters_eagerly", "false"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "query_string.lenient", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "load_fixed_bitset_filters_eagerly", "false"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "query_string_lenient", "true"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+				},
+			},
+			{
+				// the booleans read back don't drift from the configuration
+				Config:   testAccElasticsearchIndexBoolSettings,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_importIntSettings(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("typeless mappings are only supported from ES 6")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexRoutingPartitionSize,
+				Check: resource.ComposeTestCheckFunc(
+					// ES returns integer settings as strings
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "routing_partition_size", "2"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "mapping.total_fields.limit", "2000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "routing_partition_size", "2"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_total_fields_limit", "2000"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+					"mappings",                // returned in the typed layout on ES 6
+				},
+			},
+			{
+				// the integers read back don't drift from the configuration
+				Config:   testAccElasticsearchIndexRoutingPartitionSize,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_routingPartitionSizeInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexRoutingPartitionSizeInvalid, 2, ""),
+				ExpectError: regexp.MustCompile(`routing_partition_size \(2\) must be less than number_of_shards \(2\)`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexRoutingPartitionSizeInvalid, 1, "number_of_routing_shards = 2"),
+				ExpectError: regexp.MustCompile(`routing_partition_size \(2\) must be less than number_of_routing_shards \(2\)`),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_jsonFiles(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("typeless mappings are only supported from ES 6")
+	}
+
+	dir, err := ioutil.TempDir("", "terraform-test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"mappings.json": `{"properties": {"name": {"type": "keyword"}}}`,
+		"aliases.json":  `{"terraform-test-alias": {}}`,
+		"settings.json": `{"index": {"max_refresh_listeners": 500}}`,
+		"invalid.json":  `{"properties":`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexJSONFilesConflict, filepath.Join(dir, "mappings.json")),
+				ExpectError: regexp.MustCompile("only one of `mappings` and `mappings_file` can be set"),
+			},
+			{
+				Config:      fmt.Sprintf(testAccElasticsearchIndexJSONFiles, filepath.Join(dir, "invalid.json"), filepath.Join(dir, "aliases.json"), filepath.Join(dir, "settings.json")),
+				ExpectError: regexp.MustCompile("mappings_file: .* must contain JSON"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexJSONFiles, filepath.Join(dir, "mappings.json"), filepath.Join(dir, "aliases.json"), filepath.Join(dir, "settings.json")),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_refresh_listeners", "500"),
+					checkElasticsearchAliasIndices("terraform-test-alias", []string{"terraform-test"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_priority(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexPriority, 10),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "priority", "10"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "priority", "10"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexPriority, 100),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "priority", "100"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "priority", "100"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_slowlog(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexSlowlogInvalid,
+				ExpectError: regexp.MustCompile("must be a time value"),
+			},
+			{
+				Config: testAccElasticsearchIndexSlowlog,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "search.slowlog.threshold.query.warn", "10s"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "search.slowlog.threshold.fetch.info", "800ms"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "indexing.slowlog.threshold.index.debug", "2s"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "indexing.slowlog.source", "500"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_slowlog_threshold_query_warn", "10s"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_slowlog_threshold_fetch_info", "800ms"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "indexing_slowlog_threshold_index_debug", "2s"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "indexing_slowlog_source", "500"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "search_slowlog_threshold_query_warn", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_storePreload(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexStorePreload,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "store_preload.#", "2"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "store_preload.0", "nvd"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "store_preload.1", "dvd"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+				},
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_importAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexAliases,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestMatchResourceAttr("elasticsearch_index.test", "aliases", regexp.MustCompile(`"index_routing":"1"`)),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+				},
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_typedMappings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexTypedMappings,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+				),
+			},
+			{
+				// the imported mappings are in the layout of the cluster
+				Config:                  testAccElasticsearchIndexTypedMappings,
+				ResourceName:            "elasticsearch_index.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"mappings", "force_destroy", "wait_for_deletion", "adopt_existing", "wait_for_status", "documents_refresh", "wait_for_rollover_alias"},
+				ImportStateCheck:        checkElasticsearchIndexImportedMappings,
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_typelessMappings(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Typeless mappings only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexTypelessMappings,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+				),
+			},
+			{
+				Config:                  testAccElasticsearchIndexTypelessMappings,
+				ResourceName:            "elasticsearch_index.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"mappings", "force_destroy", "wait_for_deletion", "adopt_existing", "wait_for_status", "documents_refresh", "wait_for_rollover_alias"},
+				ImportStateCheck:        checkElasticsearchIndexImportedMappings,
+			},
+		},
+	})
+}
 
-  depends_on = [elasticsearch_index_template.test]
+func TestAccElasticsearchIndex_raiseReplicas(t *testing.T) {
+	var uuid string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexNoReplicas,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "number_of_replicas", "0"),
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+				),
+			},
+			{
+				// raising the replicas after a bulk load must update the index in place
+				Config: testAccElasticsearchIndex,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "number_of_replicas", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "number_of_replicas", "1"),
+					checkElasticsearchIndexUUID("elasticsearch_index.test", &uuid),
+				),
+			},
+		},
+	})
 }
-`
-)
 
-func TestAccElasticsearchIndex(t *testing.T) {
+func TestAccElasticsearchIndex_recreateWithDynamicSettings(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -193,22 +2380,107 @@ func TestAccElasticsearchIndex(t *testing.T) {
 				),
 			},
 			{
-				Config: testAccElasticsearchIndexUpdate1,
+				Config: testAccElasticsearchIndexRecreate,
 				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "number_of_shards", "2"),
 					checkElasticsearchIndexUpdated("elasticsearch_index.test"),
 				),
 			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_mappingCoerce(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexMappingCoerce, true),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "mapping.ignore_malformed", "true"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "mapping.coerce", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_coerce", "true"),
+				),
+			},
 			{
-				Config: testAccElasticsearchIndexUpdateForceDestroy,
+				Config: fmt.Sprintf(testAccElasticsearchIndexMappingCoerce, false),
 				Check: resource.ComposeTestCheckFunc(
-					checkElasticsearchIndexUpdated("elasticsearch_index.test"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "mapping.coerce", "false"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "mapping_coerce", "false"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccElasticsearchIndex_handleInvalid(t *testing.T) {
+func TestAccElasticsearchIndex_waitForDeletion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexWaitForDeletion,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "wait_for_deletion", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_adoptExisting(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				// create the index out of band
+				PreConfig: func() {
+					var err error
+					switch client := esClient.(type) {
+					case *elastic7.Client:
+						_, err = client.CreateIndex("terraform-test").Do(context.TODO())
+					case *elastic6.Client:
+						_, err = client.CreateIndex("terraform-test").Do(context.TODO())
+					default:
+						elastic5Client := client.(*elastic5.Client)
+						_, err = elastic5Client.CreateIndex("terraform-test").Do(context.TODO())
+					}
+					if err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config:      fmt.Sprintf(testAccElasticsearchIndexAdoptExisting, false),
+				ExpectError: regexp.MustCompile("already_exists_exception"),
+			},
+			{
+				Config: fmt.Sprintf(testAccElasticsearchIndexAdoptExisting, true),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "id", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_searchLimits(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
 	if err != nil {
@@ -231,37 +2503,26 @@ func TestAccElasticsearchIndex_handleInvalid(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 			if !allowed {
-				t.Skip("Only tested on ES >= 6")
+				t.Skip("Search limits only supported on ES >= 6")
 			}
 		},
 		Providers:    testAccProviders,
 		CheckDestroy: checkElasticsearchIndexDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config:      testAccElasticsearchIndexInvalid,
-				ExpectError: regexp.MustCompile("Failed to parse mapping"),
-			},
-		},
-	})
-}
-
-func TestAccElasticsearchIndex_importBasic(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: checkElasticsearchIndexDestroy,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccElasticsearchIndex,
-			},
-			{
-				ResourceName:      "elasticsearch_index.test",
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					// not returned from the API
-					"force_destroy",
-				},
+				Config: testAccElasticsearchIndexSearchLimits,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_docvalue_fields_search", "50"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "highlight.max_analyzed_offset", "500000"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "max_docvalue_fields_search", "50"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "highlight_max_analyzed_offset", "500000"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_terms_count", "1024"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_regex_length", "100"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "max_terms_count", "1024"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "max_regex_length", "100"),
+					checkElasticsearchIndexSetting("elasticsearch_index.test", "max_slices_per_scroll", "512"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "max_slices_per_scroll", "512"),
+				),
 			},
 		},
 	})
@@ -319,13 +2580,36 @@ func TestAccElasticsearchIndex_rolloverAliasXpack(t *testing.T) {
 					checkElasticsearchIndexRolloverAliasExists(testAccXPackProvider, "terraform-test"),
 				),
 			},
+			{
+				Config:      strings.Replace(testAccElasticsearchIndexRolloverAliasXpack, "number_of_shards = 1", "number_of_shards = 2", 1),
+				ExpectError: regexp.MustCompile("number_of_shards can't be changed"),
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
+				},
+				ImportStateCheck: checkElasticsearchIndexRolloverAliasState("terraform-test"),
+			},
 			{
 				ResourceName:      "elasticsearch_index.test",
 				ImportState:       true,
+				ImportStateId:     "terraform-test",
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"aliases",       // not handled by this provider
-					"force_destroy", // not returned from the API
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
 				},
 				ImportStateCheck: checkElasticsearchIndexRolloverAliasState("terraform-test"),
 			},
@@ -333,6 +2617,37 @@ func TestAccElasticsearchIndex_rolloverAliasXpack(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchIndex_waitForRolloverAlias(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		t.Skip("Index lifecycles only supported on ES >= 6")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccXPackProviders,
+		CheckDestroy: checkElasticsearchIndexRolloverAliasDestroy(testAccXPackProvider, "terraform-test"),
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testAccElasticsearchIndexRolloverAliasXpack, "number_of_replicas = 1", "number_of_replicas = 1\n  wait_for_rollover_alias = true", 1),
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexRolloverAliasExists(testAccXPackProvider, "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "rollover_alias", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchIndex_rolloverAliasOpendistro(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
@@ -376,8 +2691,12 @@ func TestAccElasticsearchIndex_rolloverAliasOpendistro(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"aliases",       // not handled by this provider
-					"force_destroy", // not returned from the API
+					"force_destroy",           // not returned from the API
+					"wait_for_deletion",       // not returned from the API
+					"adopt_existing",          // not returned from the API
+					"wait_for_status",         // not returned from the API
+					"documents_refresh",       // not returned from the API
+					"wait_for_rollover_alias", // not returned from the API
 				},
 				ImportStateCheck: checkElasticsearchIndexRolloverAliasState("terraform-test"),
 			},
@@ -471,37 +2790,218 @@ func checkElasticsearchIndexUpdated(name string) resource.TestCheckFunc {
 	}
 }
 
-func checkElasticsearchIndexDestroy(s *terraform.State) error {
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "elasticsearch_index" {
-			continue
+// checkElasticsearchIndexImportedMappings checks the imported mappings are
+// equivalent to the configured ones, whatever their layout
+func checkElasticsearchIndexImportedMappings(states []*terraform.InstanceState) error {
+	expected := `{"properties": {"email": {"type": "keyword"}}}`
+	for _, state := range states {
+		mappings := state.Attributes["mappings"]
+		if !diffSuppressIndexMappings("mappings", mappings, expected, nil) {
+			return fmt.Errorf("expected imported mappings equivalent to %s, got %s", expected, mappings)
+		}
+	}
+	return nil
+}
+
+// checkElasticsearchIndexSetting checks a flattened setting of an index, an
+// empty expected value checks that it isn't set
+func checkElasticsearchIndexSetting(name, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getElasticsearchIndexSettings(s, name)
+		if err != nil {
+			return err
+		}
+
+		actual, ok := settings[key]
+		if !ok {
+			actual = ""
+		}
+		if actual != expected {
+			return fmt.Errorf("expected %s to be %q got %v", key, expected, actual)
+		}
+
+		return nil
+	}
+}
+
+// checkElasticsearchIndexSettingAttr checks that a computed attribute matches
+// a flattened setting of an index
+func checkElasticsearchIndexSettingAttr(name, key, attr string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getElasticsearchIndexSettings(s, name)
+		if err != nil {
+			return err
+		}
+
+		expected, _ := settings[key].(string)
+		if expected == "" {
+			return fmt.Errorf("expected %s to be set", key)
+		}
+		return resource.TestCheckResourceAttr(name, attr, expected)(s)
+	}
+}
+
+// checkElasticsearchIndexUUID stores the UUID of an index, or once stored,
+// checks that the index wasn't recreated
+func checkElasticsearchIndexUUID(name string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getElasticsearchIndexSettings(s, name)
+		if err != nil {
+			return err
+		}
+
+		actual, _ := settings["uuid"].(string)
+		if actual == "" {
+			return fmt.Errorf("index UUID not set")
+		}
+		if *uuid != "" && *uuid != actual {
+			return fmt.Errorf("expected index %s to be updated in place, got UUID %s instead of %s", name, actual, *uuid)
+		}
+		*uuid = actual
+
+		return nil
+	}
+}
+
+// checkElasticsearchIndexRecreated checks that an index was recreated since
+// its UUID was stored, and stores the new one
+func checkElasticsearchIndexRecreated(name string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getElasticsearchIndexSettings(s, name)
+		if err != nil {
+			return err
+		}
+
+		actual, _ := settings["uuid"].(string)
+		if actual == "" {
+			return fmt.Errorf("index UUID not set")
+		}
+		if *uuid == actual {
+			return fmt.Errorf("expected index %s to be recreated, got the same UUID %s", name, actual)
+		}
+		*uuid = actual
+
+		return nil
+	}
+}
+
+func checkElasticsearchIndexDocumentCount(name string, expected int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("index ID not set")
 		}
 
 		meta := testAccProvider.Meta()
+		var count int64
 
-		var err error
 		esClient, err := getClient(meta.(*ProviderConf))
 		if err != nil {
 			return err
 		}
 		switch client := esClient.(type) {
 		case *elastic7.Client:
-			_, err = client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			count, err = client.Count(rs.Primary.ID).Do(context.TODO())
 		case *elastic6.Client:
-			_, err = client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			count, err = client.Count(rs.Primary.ID).Do(context.TODO())
 		default:
 			elastic5Client := client.(*elastic5.Client)
-			_, err = elastic5Client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			count, err = elastic5Client.Count(rs.Primary.ID).Do(context.TODO())
+		}
+		if err != nil {
+			return err
+		}
+
+		if count != expected {
+			return fmt.Errorf("expected %d documents in %s, got %d", expected, rs.Primary.ID, count)
+		}
+		return nil
+	}
+}
+
+func getElasticsearchIndexSettings(s *terraform.State, name string) (map[string]interface{}, error) {
+	rs, ok := s.RootModule().Resources[name]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", name)
+	}
+	if rs.Primary.ID == "" {
+		return nil, fmt.Errorf("index ID not set")
+	}
+
+	meta := testAccProvider.Meta()
+	var settings map[string]interface{}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		resp, err := client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+		if err != nil {
+			return nil, err
 		}
+		settings = resp[rs.Primary.ID].Settings["index"].(map[string]interface{})
 
+	case *elastic6.Client:
+		resp, err := client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
 		if err != nil {
-			return nil // should be not found error
+			return nil, err
 		}
+		settings = resp[rs.Primary.ID].Settings["index"].(map[string]interface{})
 
-		return fmt.Errorf("index %q still exists", rs.Primary.ID)
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		resp, err := elastic5Client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		settings = resp[rs.Primary.ID].Settings["index"].(map[string]interface{})
 	}
 
-	return nil
+	return flattenMap(settings), nil
+}
+
+func checkElasticsearchIndexDestroy(s *terraform.State) error {
+	return checkElasticsearchIndexDestroyWithProvider(testAccProvider)(s)
+}
+
+func checkElasticsearchIndexDestroyWithProvider(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "elasticsearch_index" {
+				continue
+			}
+
+			meta := provider.Meta()
+
+			var err error
+			esClient, err := getClient(meta.(*ProviderConf))
+			if err != nil {
+				return err
+			}
+			switch client := esClient.(type) {
+			case *elastic7.Client:
+				_, err = client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			case *elastic6.Client:
+				_, err = client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			default:
+				elastic5Client := client.(*elastic5.Client)
+				_, err = elastic5Client.IndexGetSettings(rs.Primary.ID).Do(context.TODO())
+			}
+
+			if err != nil {
+				return nil // should be not found error
+			}
+
+			return fmt.Errorf("index %q still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
 }
 
 func checkElasticsearchIndexRolloverAliasExists(provider *schema.Provider, alias string) resource.TestCheckFunc {
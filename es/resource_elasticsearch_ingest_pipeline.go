@@ -134,12 +134,12 @@ func resourceElasticsearchIngestPipelineDelete(d *schema.ResourceData, meta inte
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.IngestDeletePipeline(id).Do(context.TODO())
+		_, err = client.IngestDeletePipeline(id).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	case *elastic6.Client:
-		_, err = client.IngestDeletePipeline(id).Do(context.TODO())
+		_, err = client.IngestDeletePipeline(id).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		_, err = elastic5Client.IngestDeletePipeline(id).Do(context.TODO())
+		_, err = elastic5Client.IngestDeletePipeline(id).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	}
 
 	if err != nil {
@@ -160,12 +160,12 @@ func resourceElasticsearchPutIngestPipeline(d *schema.ResourceData, meta interfa
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.IngestPutPipeline(name).BodyString(body).Do(context.TODO())
+		_, err = client.IngestPutPipeline(name).BodyString(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	case *elastic6.Client:
-		_, err = client.IngestPutPipeline(name).BodyString(body).Do(context.TODO())
+		_, err = client.IngestPutPipeline(name).BodyString(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		_, err = elastic5Client.IngestPutPipeline(name).BodyString(body).Do(context.TODO())
+		_, err = elastic5Client.IngestPutPipeline(name).BodyString(body).MasterTimeout(meta.(*ProviderConf).masterTimeout).Do(context.TODO())
 	}
 
 	return err
@@ -38,6 +38,28 @@ func resourceElasticsearchOpenDistroISMPolicy() *schema.Resource {
 					return json
 				},
 			},
+			"ism_template": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Templates which attach the policy to the new indices matching their index patterns, as an alternative to `policy.ism_template` in `body`, which it conflicts with. Among the policies whose templates match an index, the one with the highest priority is attached",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_patterns": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The index patterns of the new indices to attach the policy to",
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "The priority of the template among the templates matching an index",
+						},
+					},
+				},
+			},
 			"primary_term": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -78,6 +100,14 @@ func resourceElasticsearchOpenDistroISMPolicyRead(d *schema.ResourceData, m inte
 		return err
 	}
 
+	// Only read the templates into ism_template when they're not defined in
+	// the body, e.g. on import
+	templates := ismTemplatesFromPolicy(policyResponse.Policy)
+	templatesInBody := ismPolicyBodyHasTemplate(d.Get("body").(string))
+	if !templatesInBody {
+		delete(policyResponse.Policy, "ism_template")
+	}
+
 	bodyString, err := json.Marshal(policyResponse.Policy)
 	if err != nil {
 		return err
@@ -92,6 +122,11 @@ func resourceElasticsearchOpenDistroISMPolicyRead(d *schema.ResourceData, m inte
 	if err := d.Set("body", bodyStringNormalized); err != nil {
 		return fmt.Errorf("error setting body: %s", err)
 	}
+	if !templatesInBody {
+		if err := d.Set("ism_template", templates); err != nil {
+			return fmt.Errorf("error setting ism_template: %s", err)
+		}
+	}
 	if err := d.Set("primary_term", policyResponse.PrimaryTerm); err != nil {
 		return fmt.Errorf("error setting primary_term: %s", err)
 	}
@@ -187,7 +222,10 @@ func resourceElasticsearchGetOpenDistroISMPolicy(policyID string, m interface{})
 
 func resourceElasticsearchPutOpenDistroISMPolicy(d *schema.ResourceData, m interface{}) (*PutPolicyResponse, error) {
 	response := new(PutPolicyResponse)
-	policyJSON := d.Get("body").(string)
+	policyJSON, err := ismPolicyBodyWithTemplates(d.Get("body").(string), d.Get("ism_template").([]interface{}))
+	if err != nil {
+		return response, err
+	}
 	seq := d.Get("seq_no").(int)
 	primTerm := d.Get("primary_term").(int)
 	params := url.Values{}
@@ -238,6 +276,88 @@ func resourceElasticsearchPutOpenDistroISMPolicy(d *schema.ResourceData, m inter
 	return response, nil
 }
 
+// ismPolicyBodyHasTemplate returns whether a policy body defines its templates
+// in `policy.ism_template`
+func ismPolicyBodyHasTemplate(body string) bool {
+	var policy struct {
+		Policy map[string]interface{} `json:"policy"`
+	}
+	if err := json.Unmarshal([]byte(body), &policy); err != nil {
+		return false
+	}
+	_, ok := policy.Policy["ism_template"]
+	return ok
+}
+
+// ismPolicyBodyWithTemplates adds the ism_template blocks to a policy body, as
+// an object when there is only one of them, which is also understood by the
+// versions of ISM which only support a single template
+func ismPolicyBodyWithTemplates(body string, templates []interface{}) (string, error) {
+	if len(templates) == 0 {
+		return body, nil
+	}
+	if ismPolicyBodyHasTemplate(body) {
+		return "", errors.New("ism_template can't be set both as a block and in the policy body")
+	}
+
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &policy); err != nil {
+		return "", fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	inner, ok := policy["policy"].(map[string]interface{})
+	if !ok {
+		return "", errors.New("the policy body must define `policy`")
+	}
+
+	ismTemplates := make([]interface{}, 0, len(templates))
+	for _, t := range templates {
+		template := t.(map[string]interface{})
+		ismTemplates = append(ismTemplates, map[string]interface{}{
+			"index_patterns": template["index_patterns"],
+			"priority":       template["priority"],
+		})
+	}
+	if len(ismTemplates) == 1 {
+		inner["ism_template"] = ismTemplates[0]
+	} else {
+		inner["ism_template"] = ismTemplates
+	}
+
+	bytes, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ismTemplatesFromPolicy returns the templates of a policy, which are a single
+// object or a list of them depending on the version of ISM, without the time
+// they were last updated, which is also removed from the policy
+func ismTemplatesFromPolicy(policy map[string]interface{}) []map[string]interface{} {
+	var raw []interface{}
+	switch t := policy["ism_template"].(type) {
+	case map[string]interface{}:
+		raw = []interface{}{t}
+	case []interface{}:
+		raw = t
+	}
+
+	templates := make([]map[string]interface{}, 0, len(raw))
+	for _, r := range raw {
+		template, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(template, "last_updated_time")
+		priority, _ := template["priority"].(float64)
+		templates = append(templates, map[string]interface{}{
+			"index_patterns": template["index_patterns"],
+			"priority":       int(priority),
+		})
+	}
+	return templates
+}
+
 type GetPolicyResponse struct {
 	PolicyID    string                 `json:"_id"`
 	Version     int                    `json:"_version"`
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/olivere/elastic/uritemplates"
@@ -71,6 +73,13 @@ func resourceElasticsearchOpenDistroISMPolicyMappingCreate(d *schema.ResourceDat
 }
 
 func resourceElasticsearchOpenDistroISMPolicyMappingRead(d *schema.ResourceData, m interface{}) error {
+	// On import, the ID is the indexes pattern
+	if _, ok := d.GetOk("indexes"); !ok {
+		if err := d.Set("indexes", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	indexesList, err := resourceElasticsearchGetOpendistroPolicyMapping(d, m)
 	concernIndexes := []string{}
 	policyName := d.Get("policy_id").(string)
@@ -79,9 +88,36 @@ func resourceElasticsearchOpenDistroISMPolicyMappingRead(d *schema.ResourceData,
 		return err
 	}
 
+	policies := make(map[string]bool)
+	for _, parameters := range indexesList {
+		// the explain response also has totals besides the indices
+		if parameters, ok := parameters.(map[string]interface{}); ok {
+			if policy, ok := parameters["index.opendistro.index_state_management.policy_id"].(string); ok && policy != "" {
+				policies[policy] = true
+			}
+		}
+	}
+	// On import, the policy is the one which manages the indices
+	if policyName == "" {
+		if len(policies) > 1 {
+			names := make([]string, 0, len(policies))
+			for policy := range policies {
+				names = append(names, policy)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("the indices %s are managed by several policies, %s", d.Id(), strings.Join(names, ", "))
+		}
+		for policy := range policies {
+			policyName = policy
+		}
+		if err := d.Set("policy_id", policyName); err != nil {
+			return err
+		}
+	}
+
 	// If there is no managed indexes we can remove that resource
 	for indexName, parameters := range indexesList {
-		if parameters.(map[string]interface{})["index.opendistro.index_state_management.policy_id"] == policyName {
+		if parameters, ok := parameters.(map[string]interface{}); ok && policyName != "" && parameters["index.opendistro.index_state_management.policy_id"] == policyName {
 			concernIndexes = append(concernIndexes, indexName)
 		}
 	}
@@ -0,0 +1,88 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func TestAccElasticsearchOpenDistroISMPolicyMapping(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	esClient, err := getClient(provider.Meta().(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("OpenDistroISMPolicies only supported on ES 7.")
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenDistroISMPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenDistroISMPolicyMapping,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_ism_policy_mapping.test", "id", "terraform-test-ism-*"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_ism_policy_mapping.test", "managed_indexes.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_opendistro_ism_policy_mapping.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"state",   // not returned from the API
+					"is_safe", // not returned from the API
+				},
+			},
+		},
+	})
+}
+
+var testAccElasticsearchOpenDistroISMPolicyMapping = `
+resource "elasticsearch_opendistro_ism_policy" "test_policy" {
+  policy_id = "test_policy"
+  body      = <<EOF
+{
+  "policy": {
+    "description": "Terraform Test",
+    "schema_version": 1,
+    "default_state": "hot",
+    "states": [
+      {
+        "name": "hot",
+        "actions": [],
+        "transitions": []
+      }
+    ]
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-ism-000001"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_opendistro_ism_policy_mapping" "test" {
+  policy_id = elasticsearch_opendistro_ism_policy.test_policy.id
+  indexes   = "terraform-test-ism-*"
+
+  depends_on = [elasticsearch_index.test]
+}
+`
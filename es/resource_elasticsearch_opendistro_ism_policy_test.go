@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
+
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
 	elastic6 "gopkg.in/olivere/elastic.v6"
@@ -60,6 +62,49 @@ func TestAccElasticsearchOpenDistroISMPolicy(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchOpenDistroISMPolicy_ismTemplate(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	conf := provider.Meta().(*ProviderConf)
+	if _, err := getClient(conf); err != nil {
+		t.Skipf("err: %s", err)
+	}
+	elasticVersion, err := version.NewVersion(conf.esVersion)
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if elasticVersion.LessThan(version.Must(version.NewVersion("7.10.0"))) {
+				t.Skip("ISM templates are only supported from Open Distro 1.13, on ES 7.10")
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenDistroISMPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenDistroISMPolicyISMTemplate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenDistroISMPolicyExists("elasticsearch_opendistro_ism_policy.test_policy"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_ism_policy.test_policy", "ism_template.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_ism_policy.test_policy", "ism_template.0.index_patterns.0", "terraform-test-*"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_ism_policy.test_policy", "ism_template.0.priority", "100"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_opendistro_ism_policy.test_policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testCheckElasticsearchOpenDistroISMPolicyExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -172,3 +217,30 @@ resource "elasticsearch_opendistro_ism_policy" "test_policy" {
   EOF
 }
 `
+
+var testAccElasticsearchOpenDistroISMPolicyISMTemplate = `
+resource "elasticsearch_opendistro_ism_policy" "test_policy" {
+	policy_id = "test_policy"
+	body      = <<EOF
+  {
+	"policy": {
+	  "description": "ingesting logs",
+	  "schema_version": 1,
+	  "default_state": "ingest",
+	  "states": [
+		{
+		  "name": "ingest",
+		  "actions": [],
+		  "transitions": []
+		}
+	  ]
+	}
+  }
+  EOF
+
+	ism_template {
+		index_patterns = ["terraform-test-*"]
+		priority       = 100
+	}
+}
+`
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/olivere/elastic/uritemplates"
@@ -114,10 +115,14 @@ func resourceElasticsearchOpenDistroUserDelete(d *schema.ResourceData, m interfa
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 			Method: "DELETE",
 			Path:   path,
 		})
+		if res != nil {
+			err = opendistroSecurityError(err, res.Body)
+		}
 	default:
 		err = errors.New("Role resource not implemented prior to Elastic v7")
 	}
@@ -149,13 +154,15 @@ func resourceElasticsearchGetOpenDistroUser(userID string, m interface{}) (UserB
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if res != nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("Role resource not implemented prior to Elastic v7")
 	}
 
 	if err != nil {
-		return *user, err
+		return *user, opendistroSecurityError(err, body)
 	}
 	var userDefinition map[string]UserBody
 
@@ -209,13 +216,17 @@ func resourceElasticsearchPutOpenDistroUser(d *schema.ResourceData, m interface{
 			Path:   path,
 			Body:   string(userJSON),
 		})
-		body = res.Body
+		if res != nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("User resource not implemented prior to Elastic v7")
 	}
 
+	// The body of the user isn't included in the error, as it may contain the
+	// password
 	if err != nil {
-		return response, fmt.Errorf("Error creating user: %+v: %+v: %+v", err, body, string(userJSON))
+		return response, fmt.Errorf("Error creating user: %+v: %s", opendistroSecurityError(err, body), body)
 	}
 
 	if err := json.Unmarshal(body, response); err != nil {
@@ -225,6 +236,16 @@ func resourceElasticsearchPutOpenDistroUser(d *schema.ResourceData, m interface{
 	return response, nil
 }
 
+// opendistroSecurityError explains the error returned by a cluster which
+// doesn't have the Open Distro security plugin installed, which has no
+// handler for the security APIs
+func opendistroSecurityError(err error, body json.RawMessage) error {
+	if err != nil && strings.Contains(string(body), "no handler found for uri") {
+		return fmt.Errorf("the Open Distro security plugin is not installed on this cluster: %v: %s", err, body)
+	}
+	return err
+}
+
 // UserBody used by the odfe's API
 type UserBody struct {
 	BackendRoles []interface{}          `json:"backend_roles"`
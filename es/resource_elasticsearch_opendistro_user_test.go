@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -114,6 +115,33 @@ func TestAccElasticsearchOpenDistroUser(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchOpenDistroUser_noSecurityPlugin(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		t.Skip("Users only supported on ES >= 7")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOpenDistroUserResourceMinimal("test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)),
+				ExpectError: regexp.MustCompile("security plugin is not installed"),
+			},
+		},
+	})
+}
+
 func testAccCheckElasticsearchOpenDistroUserDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "elasticsearch_opendistro_user" {
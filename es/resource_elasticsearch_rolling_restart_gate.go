@@ -0,0 +1,197 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+const clusterRoutingAllocationEnable = "cluster.routing.allocation.enable"
+
+func resourceElasticsearchRollingRestartGate() *schema.Resource {
+	return &schema.Resource{
+		Description: "Brackets a rolling restart of the cluster: creating the resource restricts shard allocation to primaries and flushes the indices, so that the restarted nodes recover their shards quickly, destroying it re-enables the allocation of all shards. If the allocation is re-enabled outside of the provider, the next apply restricts it again. It manages the persistent `cluster.routing.allocation.enable` setting, which shouldn't also be managed by `elasticsearch_cluster_settings`, and resets the transient one, which would take precedence. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/restart-cluster.html#restart-cluster-rolling) for more details.",
+		Create:      resourceElasticsearchRollingRestartGateCreate,
+		Read:        resourceElasticsearchRollingRestartGateRead,
+		Update:      resourceElasticsearchRollingRestartGateUpdate,
+		Delete:      resourceElasticsearchRollingRestartGateDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_routing_allocation_enable": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "primaries",
+				ValidateFunc: validation.StringInSlice([]string{"primaries", "new_primaries", "none"}, false),
+				Description:  "Which shards can be allocated during the restart, `primaries`, `new_primaries` or `none`",
+			},
+			"synced_flush": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Perform a synced flush of all the indices after restricting the allocation, falling back to a regular flush when some shards can't be synced, e.g. because of ongoing indexing, otherwise only perform a regular flush",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchRollingRestartGateCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := closeRollingRestartGate(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId("rolling_restart_gate")
+	return resourceElasticsearchRollingRestartGateRead(d, meta)
+}
+
+func resourceElasticsearchRollingRestartGateRead(d *schema.ResourceData, meta interface{}) error {
+	params := url.Values{}
+	params.Set("flat_settings", "true")
+	body, err := clusterSettingsRequest(meta, "GET", params, nil)
+	if err != nil {
+		return err
+	}
+	var settings struct {
+		Persistent map[string]interface{} `json:"persistent"`
+		Transient  map[string]interface{} `json:"transient"`
+	}
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return fmt.Errorf("error unmarshalling cluster settings body: %+v: %+v", err, body)
+	}
+
+	// the transient setting takes precedence, and the allocation of all shards
+	// is enabled when neither is set
+	enable := "all"
+	if value, ok := settings.Transient[clusterRoutingAllocationEnable].(string); ok {
+		enable = value
+	} else if value, ok := settings.Persistent[clusterRoutingAllocationEnable].(string); ok {
+		enable = value
+	}
+	if enable != d.Get("cluster_routing_allocation_enable").(string) {
+		log.Printf("[WARN] Shard allocation is %s instead of %s, it will be restricted again", enable, d.Get("cluster_routing_allocation_enable"))
+	}
+
+	return d.Set("cluster_routing_allocation_enable", enable)
+}
+
+func resourceElasticsearchRollingRestartGateUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("cluster_routing_allocation_enable") {
+		if err := closeRollingRestartGate(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchRollingRestartGateRead(d, meta)
+}
+
+func resourceElasticsearchRollingRestartGateDelete(d *schema.ResourceData, meta interface{}) error {
+	return putRollingRestartGateSetting(meta, nil)
+}
+
+// closeRollingRestartGate restricts the shard allocation, then flushes the
+// indices so that the shards of the restarted nodes don't replay operations
+func closeRollingRestartGate(d *schema.ResourceData, meta interface{}) error {
+	if err := putRollingRestartGateSetting(meta, d.Get("cluster_routing_allocation_enable").(string)); err != nil {
+		return err
+	}
+
+	if d.Get("synced_flush").(bool) {
+		// a synced flush is best effort, ES fails it for the shards with
+		// ongoing operations
+		err := flushRequest(meta, "/_flush/synced")
+		if err == nil {
+			return nil
+		}
+		log.Printf("[WARN] Synced flush failed, performing a regular flush: %+v", err)
+	}
+	return flushRequest(meta, "/_flush")
+}
+
+// putRollingRestartGateSetting updates the persistent allocation setting, or
+// resets it when nil, and resets the transient one, which would otherwise
+// take precedence
+func putRollingRestartGateSetting(meta interface{}, enable interface{}) error {
+	params := url.Values{}
+	if masterTimeout := meta.(*ProviderConf).masterTimeout; masterTimeout != "" {
+		params.Set("master_timeout", masterTimeout)
+	}
+	_, err := clusterSettingsRequest(meta, "PUT", params, map[string]interface{}{
+		"persistent": map[string]interface{}{
+			clusterRoutingAllocationEnable: enable,
+		},
+		"transient": map[string]interface{}{
+			clusterRoutingAllocationEnable: nil,
+		},
+	})
+	return err
+}
+
+// flushRequest flushes all the indices
+func flushRequest(meta interface{}, path string) error {
+	var (
+		ctx  = context.Background()
+		body json.RawMessage
+	)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "POST",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(ctx, elastic6.PerformRequestOptions{
+			Method: "POST",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(ctx, "POST", path, nil, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error requesting POST %s: %+v", path, err)
+	}
+
+	var resp struct {
+		Shards struct {
+			Total      int `json:"total"`
+			Successful int `json:"successful"`
+			Failed     int `json:"failed"`
+		} `json:"_shards"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("error unmarshalling %s body: %+v: %+v", path, err, body)
+	}
+	if resp.Shards.Failed > 0 {
+		return fmt.Errorf("%s failed on %d of %d shards", path, resp.Shards.Failed, resp.Shards.Total)
+	}
+	log.Printf("[INFO] Flushed %d shards with %s", resp.Shards.Successful, path)
+	return nil
+}
@@ -0,0 +1,85 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchRollingRestartGate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchRollingRestartGateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchRollingRestartGate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_rolling_restart_gate.test", "cluster_routing_allocation_enable", "primaries"),
+					checkElasticsearchClusterSetting(clusterRoutingAllocationEnable, "primaries"),
+				),
+			},
+			{
+				// re-enabling the allocation outside of the provider restricts
+				// it again
+				PreConfig: func() {
+					err := putPersistentClusterSettings(testAccProvider.Meta(), map[string]interface{}{
+						clusterRoutingAllocationEnable: "all",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccElasticsearchRollingRestartGate,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchClusterSetting(clusterRoutingAllocationEnable, "primaries"),
+				),
+			},
+			{
+				// a transient setting would take precedence over the gate
+				PreConfig: func() {
+					_, err := clusterSettingsRequest(testAccProvider.Meta(), "PUT", nil, map[string]interface{}{
+						"transient": map[string]interface{}{
+							clusterRoutingAllocationEnable: "all",
+						},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccElasticsearchRollingRestartGate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_rolling_restart_gate.test", "cluster_routing_allocation_enable", "primaries"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_rolling_restart_gate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"synced_flush",
+				},
+			},
+		},
+	})
+}
+
+func checkElasticsearchRollingRestartGateDestroy(s *terraform.State) error {
+	settings, err := getPersistentClusterSettings()
+	if err != nil {
+		return err
+	}
+
+	if value, ok := settings[clusterRoutingAllocationEnable]; ok {
+		return fmt.Errorf("cluster setting %s still set to %v", clusterRoutingAllocationEnable, value)
+	}
+	return nil
+}
+
+var testAccElasticsearchRollingRestartGate = `
+resource "elasticsearch_rolling_restart_gate" "test" {
+  cluster_routing_allocation_enable = "primaries"
+}
+`
@@ -0,0 +1,238 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// StoredScript is the script of a `_scripts/{id}` request or response, ES 5
+// returns the source as `code`
+type StoredScript struct {
+	Lang   string `json:"lang"`
+	Source string `json:"source,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+type storedScriptResponse struct {
+	ID     string       `json:"_id"`
+	Found  bool         `json:"found"`
+	Script StoredScript `json:"script"`
+}
+
+func resourceElasticsearchScript() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch stored script resource.",
+		Create:      resourceElasticsearchScriptCreate,
+		Read:        resourceElasticsearchScriptRead,
+		Update:      resourceElasticsearchScriptUpdate,
+		Delete:      resourceElasticsearchScriptDelete,
+		Schema: map[string]*schema.Schema{
+			"script_id": {
+				Type:        schema.TypeString,
+				Description: "Identifier for the stored script",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"lang": {
+				Type:         schema.TypeString,
+				Description:  "Specifies the language the script is written in, one of `painless`, `expression` or `mustache`",
+				Optional:     true,
+				Default:      "painless",
+				ValidateFunc: validation.StringInSlice([]string{"painless", "expression", "mustache"}, false),
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "The source of the stored script",
+				Required:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchScriptCreate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Get("script_id").(string)
+	script := StoredScript{
+		Lang:   d.Get("lang").(string),
+		Source: d.Get("source").(string),
+	}
+
+	if err := elasticsearchPutScript(id, script, meta); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchScriptRead(d, meta)
+}
+
+func resourceElasticsearchScriptRead(d *schema.ResourceData, meta interface{}) error {
+	script, err := elasticsearchGetScript(d.Id(), meta)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Script (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	source := script.Source
+	if source == "" {
+		source = script.Code
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("script_id", d.Id())
+	ds.set("lang", script.Lang)
+	ds.set("source", source)
+	return ds.err
+}
+
+func resourceElasticsearchScriptUpdate(d *schema.ResourceData, meta interface{}) error {
+	script := StoredScript{
+		Lang:   d.Get("lang").(string),
+		Source: d.Get("source").(string),
+	}
+
+	if err := elasticsearchPutScript(d.Id(), script, meta); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchScriptRead(d, meta)
+}
+
+func resourceElasticsearchScriptDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := uritemplates.Expand("/_scripts/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for script: %+v", err)
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "DELETE",
+			Path:   path,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "DELETE",
+			Path:   path,
+		})
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		_, err = elastic5Client.PerformRequest(context.TODO(), "DELETE", path, nil, nil)
+	}
+
+	return err
+}
+
+func elasticsearchGetScript(id string, meta interface{}) (StoredScript, error) {
+	var (
+		response storedScriptResponse
+		body     json.RawMessage
+	)
+
+	path, err := uritemplates.Expand("/_scripts/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return response.Script, fmt.Errorf("error building URL path for script: %+v", err)
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return response.Script, err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(context.TODO(), "GET", path, nil, nil)
+		if err == nil {
+			body = res.Body
+		}
+	}
+
+	if err != nil {
+		return response.Script, err
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return response.Script, fmt.Errorf("error unmarshalling script body: %+v: %+v", err, body)
+	}
+
+	return response.Script, nil
+}
+
+func elasticsearchPutScript(id string, script StoredScript, meta interface{}) error {
+	body := map[string]interface{}{
+		"script": script,
+	}
+
+	path, err := uritemplates.Expand("/_scripts/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for script: %+v", err)
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "PUT",
+			Path:   path,
+			Body:   body,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "PUT",
+			Path:   path,
+			Body:   body,
+		})
+	default:
+		elastic5Client := client.(*elastic5.Client)
+		_, err = elastic5Client.PerformRequest(context.TODO(), "PUT", path, nil, body)
+	}
+
+	return err
+}
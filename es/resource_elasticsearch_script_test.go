@@ -0,0 +1,86 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchScript(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchScript,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchScriptExists("elasticsearch_script.test_script"),
+					resource.TestCheckResourceAttr("elasticsearch_script.test_script", "lang", "painless"),
+				),
+			},
+			{
+				Config: testAccElasticsearchScriptUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchScriptExists("elasticsearch_script.test_script"),
+					resource.TestCheckResourceAttr("elasticsearch_script.test_script", "source", "Math.log(_score * 4) + params.my_modifier"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_script.test_script",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchScriptExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No script ID is set")
+		}
+
+		_, err := elasticsearchGetScript(rs.Primary.ID, testAccProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchScriptDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_script" {
+			continue
+		}
+
+		_, err := elasticsearchGetScript(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Script %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchScript = `
+resource "elasticsearch_script" "test_script" {
+  script_id = "terraform-test"
+  lang      = "painless"
+  source    = "Math.log(_score * 2) + params.my_modifier"
+}
+`
+
+var testAccElasticsearchScriptUpdate = `
+resource "elasticsearch_script" "test_script" {
+  script_id = "terraform-test"
+  lang      = "painless"
+  source    = "Math.log(_score * 4) + params.my_modifier"
+}
+`
@@ -0,0 +1,166 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchSearchTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an Elasticsearch search template resource, a stored mustache script.",
+		Create:        resourceElasticsearchSearchTemplateCreate,
+		Read:          resourceElasticsearchSearchTemplateRead,
+		Update:        resourceElasticsearchSearchTemplateUpdate,
+		Delete:        resourceElasticsearchScriptDelete,
+		CustomizeDiff: resourceElasticsearchSearchTemplateCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:        schema.TypeString,
+				Description: "Identifier for the search template",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source": {
+				Type:             schema.TypeString,
+				Description:      "The mustache template of the search request body",
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+			},
+			"validate": {
+				Type:        schema.TypeBool,
+				Description: "Render the template with `params` during plan to catch template errors early",
+				Optional:    true,
+				Default:     false,
+			},
+			"params": {
+				Type:         schema.TypeString,
+				Description:  "A JSON object of sample params to render the template with when `validate` is set",
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchSearchTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Get("template_id").(string)
+	script := StoredScript{
+		Lang:   "mustache",
+		Source: d.Get("source").(string),
+	}
+
+	if err := elasticsearchPutScript(id, script, meta); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchSearchTemplateRead(d, meta)
+}
+
+func resourceElasticsearchSearchTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	script, err := elasticsearchGetScript(d.Id(), meta)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Search template (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	source := script.Source
+	if source == "" {
+		source = script.Code
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("template_id", d.Id())
+	ds.set("source", source)
+	return ds.err
+}
+
+func resourceElasticsearchSearchTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("source") {
+		script := StoredScript{
+			Lang:   "mustache",
+			Source: d.Get("source").(string),
+		}
+
+		if err := elasticsearchPutScript(d.Id(), script, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchSearchTemplateRead(d, meta)
+}
+
+func resourceElasticsearchSearchTemplateCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate").(bool) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("source") && !d.HasChange("params") {
+		return nil
+	}
+	// the template can't be rendered until interpolated values are known
+	if !d.NewValueKnown("source") || !d.NewValueKnown("params") {
+		return nil
+	}
+
+	params := make(map[string]interface{})
+	if raw := d.Get("params").(string); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params); err != nil {
+			return fmt.Errorf("fail to unmarshal params: %v", err)
+		}
+	}
+
+	if err := elasticsearchRenderSearchTemplate(d.Get("source").(string), params, meta); err != nil {
+		return fmt.Errorf("search template %q failed to render: %+v", d.Get("template_id").(string), err)
+	}
+	return nil
+}
+
+func elasticsearchRenderSearchTemplate(source string, params map[string]interface{}, meta interface{}) error {
+	body := map[string]interface{}{
+		"source": source,
+		"params": params,
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "POST",
+			Path:   "/_render/template",
+			Body:   body,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: "POST",
+			Path:   "/_render/template",
+			Body:   body,
+		})
+	default:
+		// ES 5 names the template source `inline`
+		body["inline"] = body["source"]
+		delete(body, "source")
+		elastic5Client := client.(*elastic5.Client)
+		_, err = elastic5Client.PerformRequest(context.TODO(), "POST", "/_render/template", nil, body)
+	}
+
+	return err
+}
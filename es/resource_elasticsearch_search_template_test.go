@@ -0,0 +1,100 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchSearchTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchSearchTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchSearchTemplateInvalid,
+				ExpectError: regexp.MustCompile("failed to render"),
+			},
+			{
+				Config: testAccElasticsearchSearchTemplate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSearchTemplateExists("elasticsearch_search_template.test"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_search_template.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"validate", "params"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchSearchTemplateExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No search template ID is set")
+		}
+
+		script, err := elasticsearchGetScript(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+		if script.Lang != "mustache" {
+			return fmt.Errorf("expected a mustache script, got %q", script.Lang)
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchSearchTemplateDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_search_template" {
+			continue
+		}
+
+		_, err := elasticsearchGetScript(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Search template %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchSearchTemplate = `
+resource "elasticsearch_search_template" "test" {
+  template_id = "terraform-test"
+  source = jsonencode({
+    query = {
+      match = {
+        message = "{{query_string}}"
+      }
+    }
+  })
+
+  validate = true
+  params = jsonencode({
+    query_string = "hello world"
+  })
+}
+`
+
+var testAccElasticsearchSearchTemplateInvalid = `
+resource "elasticsearch_search_template" "test" {
+  template_id = "terraform-test"
+  source      = "{\"query\": {\"match\": {\"message\": \"{{#query_string}}\"}}}"
+  validate    = true
+}
+`
@@ -120,44 +120,44 @@ func resourceElasticsearchSnapshotRepositoryUpdate(d *schema.ResourceData, meta
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		err = elastic7SnapshotCreateRepository(client, name, repositoryType, settings)
+		err = elastic7SnapshotCreateRepository(client, name, repositoryType, settings, meta.(*ProviderConf).masterTimeout)
 	case *elastic6.Client:
-		err = elastic6SnapshotCreateRepository(client, name, repositoryType, settings)
+		err = elastic6SnapshotCreateRepository(client, name, repositoryType, settings, meta.(*ProviderConf).masterTimeout)
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		err = elastic5SnapshotCreateRepository(elastic5Client, name, repositoryType, settings)
+		err = elastic5SnapshotCreateRepository(elastic5Client, name, repositoryType, settings, meta.(*ProviderConf).masterTimeout)
 	}
 
 	return err
 }
 
-func elastic7SnapshotCreateRepository(client *elastic7.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic7SnapshotCreateRepository(client *elastic7.Client, name string, repositoryType string, settings map[string]interface{}, masterTimeout string) error {
 	repo := elastic7.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic6SnapshotCreateRepository(client *elastic6.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic6SnapshotCreateRepository(client *elastic6.Client, name string, repositoryType string, settings map[string]interface{}, masterTimeout string) error {
 	repo := elastic6.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic5SnapshotCreateRepository(client *elastic5.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic5SnapshotCreateRepository(client *elastic5.Client, name string, repositoryType string, settings map[string]interface{}, masterTimeout string) error {
 	repo := elastic5.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
@@ -171,12 +171,12 @@ func resourceElasticsearchSnapshotRepositoryDelete(d *schema.ResourceData, meta
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		err = elastic7SnapshotDeleteRepository(client, id)
+		err = elastic7SnapshotDeleteRepository(client, id, meta.(*ProviderConf).masterTimeout)
 	case *elastic6.Client:
-		err = elastic6SnapshotDeleteRepository(client, id)
+		err = elastic6SnapshotDeleteRepository(client, id, meta.(*ProviderConf).masterTimeout)
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		err = elastic5SnapshotDeleteRepository(elastic5Client, id)
+		err = elastic5SnapshotDeleteRepository(elastic5Client, id, meta.(*ProviderConf).masterTimeout)
 	}
 
 	if err != nil {
@@ -186,17 +186,17 @@ func resourceElasticsearchSnapshotRepositoryDelete(d *schema.ResourceData, meta
 	return nil
 }
 
-func elastic7SnapshotDeleteRepository(client *elastic7.Client, id string) error {
-	_, err := client.SnapshotDeleteRepository(id).Do(context.TODO())
+func elastic7SnapshotDeleteRepository(client *elastic7.Client, id string, masterTimeout string) error {
+	_, err := client.SnapshotDeleteRepository(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic6SnapshotDeleteRepository(client *elastic6.Client, id string) error {
-	_, err := client.SnapshotDeleteRepository(id).Do(context.TODO())
+func elastic6SnapshotDeleteRepository(client *elastic6.Client, id string, masterTimeout string) error {
+	_, err := client.SnapshotDeleteRepository(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
 
-func elastic5SnapshotDeleteRepository(client *elastic5.Client, id string) error {
-	_, err := client.SnapshotDeleteRepository(id).Do(context.TODO())
+func elastic5SnapshotDeleteRepository(client *elastic5.Client, id string, masterTimeout string) error {
+	_, err := client.SnapshotDeleteRepository(id).MasterTimeout(masterTimeout).Do(context.TODO())
 	return err
 }
@@ -0,0 +1,382 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+var ccrAutofollowPatternMinimalVersion, _ = version.NewVersion("6.5.0")
+
+// autoFollowIntParameters and autoFollowStringParameters are the follow
+// settings of the replicated indices
+var (
+	autoFollowIntParameters = []string{
+		"max_read_request_operation_count",
+		"max_outstanding_read_requests",
+		"max_write_request_operation_count",
+		"max_outstanding_write_requests",
+		"max_write_buffer_count",
+	}
+	autoFollowStringParameters = []string{
+		"max_read_request_size",
+		"max_write_request_size",
+		"max_write_buffer_size",
+		"max_retry_delay",
+		"read_poll_timeout",
+	}
+)
+
+func resourceElasticsearchXpackCcrAutofollowPattern() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack cross-cluster replication auto-follow pattern, which automatically follows the indices created on a remote cluster matching a set of patterns (ES >= 6.5). See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ccr-put-auto-follow-pattern.html) for more details.",
+		Create:      resourceElasticsearchXpackCcrAutofollowPatternCreate,
+		Read:        resourceElasticsearchXpackCcrAutofollowPatternRead,
+		Update:      resourceElasticsearchXpackCcrAutofollowPatternUpdate,
+		Delete:      resourceElasticsearchXpackCcrAutofollowPatternDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the auto-follow pattern",
+			},
+			"remote_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The remote cluster containing the leader indices",
+			},
+			"leader_index_patterns": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Index patterns matching the leader indices on the remote cluster to follow",
+			},
+			"follow_index_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the follower indices, `{{leader_index}}` is replaced by the name of the leader index, defaults to the name of the leader index",
+			},
+			"max_read_request_operation_count": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of operations to pull per read from the remote cluster",
+			},
+			"max_outstanding_read_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of outstanding reads requests from the remote cluster",
+			},
+			"max_read_request_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum size in bytes of per read of a batch of operations pulled from the remote cluster, e.g. `32mb`",
+			},
+			"max_write_request_operation_count": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of operations per bulk write request executed on the follower",
+			},
+			"max_write_request_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum total bytes of operations per bulk write request executed on the follower, e.g. `32mb`",
+			},
+			"max_outstanding_write_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of outstanding write requests on the follower",
+			},
+			"max_write_buffer_count": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of operations that can be queued for writing",
+			},
+			"max_write_buffer_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum total bytes of operations that can be queued for writing, e.g. `512mb`",
+			},
+			"max_retry_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `500ms`"),
+				Description:  "The maximum time to wait before retrying an operation that failed exceptionally, e.g. `500ms`",
+			},
+			"read_poll_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a time value, e.g. `1m`"),
+				Description:  "The maximum time to wait for new operations on the remote cluster when the follower indices are synchronized with the leader indices, e.g. `1m`",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackCcrAutofollowPatternCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	if err := resourceElasticsearchPutCcrAutofollowPattern(d, meta); err != nil {
+		return err
+	}
+	d.SetId(name)
+	return resourceElasticsearchXpackCcrAutofollowPatternRead(d, meta)
+}
+
+func resourceElasticsearchXpackCcrAutofollowPatternRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	var pattern map[string]interface{}
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if err := checkCcrAutofollowPatternVersion(esClient); err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		pattern, err = elastic7GetCcrAutofollowPattern(client, id)
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Auto-follow pattern (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+	case *elastic6.Client:
+		pattern, err = elastic6GetCcrAutofollowPattern(client, id)
+		if elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Auto-follow pattern (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+	default:
+		err = errors.New("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("remote_cluster", pattern["remote_cluster"])
+	ds.set("leader_index_patterns", pattern["leader_index_patterns"])
+	ds.set("follow_index_pattern", pattern["follow_index_pattern"])
+	for _, key := range autoFollowIntParameters {
+		if value, ok := pattern[key].(float64); ok {
+			ds.set(key, int(value))
+		}
+	}
+	for _, key := range autoFollowStringParameters {
+		if value, ok := pattern[key]; ok {
+			ds.set(key, value)
+		}
+	}
+	return ds.err
+}
+
+func resourceElasticsearchXpackCcrAutofollowPatternUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutCcrAutofollowPattern(d, meta); err != nil {
+		return err
+	}
+	return resourceElasticsearchXpackCcrAutofollowPatternRead(d, meta)
+}
+
+func resourceElasticsearchXpackCcrAutofollowPatternDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := ccrAutofollowPatternPath(d.Id())
+	if err != nil {
+		return err
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: http.MethodDelete,
+			Path:   path,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: http.MethodDelete,
+			Path:   path,
+		})
+	default:
+		err = errors.New("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchPutCcrAutofollowPattern(d *schema.ResourceData, meta interface{}) error {
+	path, err := ccrAutofollowPatternPath(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"remote_cluster":        d.Get("remote_cluster").(string),
+		"leader_index_patterns": d.Get("leader_index_patterns").([]interface{}),
+	}
+	if v, ok := d.GetOk("follow_index_pattern"); ok {
+		body["follow_index_pattern"] = v
+	}
+	for _, key := range append(autoFollowIntParameters, autoFollowStringParameters...) {
+		if v, ok := d.GetOk(key); ok {
+			body[key] = v
+		}
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if err := checkCcrAutofollowPatternVersion(esClient); err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   path,
+			Body:   body,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   path,
+			Body:   body,
+		})
+	default:
+		err = errors.New("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+
+	return err
+}
+
+// checkCcrAutofollowPatternVersion rejects the versions without the auto-follow
+// API, which would otherwise fail with a "no handler found" error
+func checkCcrAutofollowPatternVersion(esClient interface{}) error {
+	var (
+		elasticVersion *version.Version
+		err            error
+	)
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err = elastic7GetVersion(client)
+	case *elastic6.Client:
+		elasticVersion, err = elastic6GetVersion(client)
+	default:
+		return errors.New("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(ccrAutofollowPatternMinimalVersion) {
+		return fmt.Errorf("auto-follow patterns are only available from ElasticSearch >= 6.5, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func ccrAutofollowPatternPath(name string) (string, error) {
+	path, err := uritemplates.Expand("/_ccr/auto_follow/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for auto-follow pattern: %+v", err)
+	}
+	return path, nil
+}
+
+// ccrAutofollowPatternFromResponse returns a pattern from the list of patterns
+// returned by the API, even when requesting a single one, ES < 6.7 returns
+// them keyed by name instead
+func ccrAutofollowPatternFromResponse(body json.RawMessage, name string) (map[string]interface{}, error) {
+	var resp struct {
+		Patterns []struct {
+			Name    string                 `json:"name"`
+			Pattern map[string]interface{} `json:"pattern"`
+		} `json:"patterns"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling auto-follow pattern body: %+v: %+v", err, body)
+	}
+
+	for _, pattern := range resp.Patterns {
+		if pattern.Name == name {
+			return pattern.Pattern, nil
+		}
+	}
+
+	var keyed map[string]interface{}
+	if err := json.Unmarshal(body, &keyed); err == nil {
+		if pattern, ok := keyed[name].(map[string]interface{}); ok {
+			return pattern, nil
+		}
+	}
+	return nil, fmt.Errorf("auto-follow pattern %s not found in response", name)
+}
+
+func elastic7GetCcrAutofollowPattern(client *elastic7.Client, name string) (map[string]interface{}, error) {
+	path, err := ccrAutofollowPatternPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ccrAutofollowPatternFromResponse(res.Body, name)
+}
+
+func elastic6GetCcrAutofollowPattern(client *elastic6.Client, name string) (map[string]interface{}, error) {
+	path, err := ccrAutofollowPatternPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ccrAutofollowPatternFromResponse(res.Body, name)
+}
@@ -0,0 +1,180 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func TestAccElasticsearchXpackCcrAutofollowPattern(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic7.Client:
+		allowed = true
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Auto-follow patterns only tested on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackCcrAutofollowPatternDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackCcrAutofollowPatternLeader,
+			},
+			{
+				// the cluster replicates from itself
+				PreConfig: func() {
+					if err := testAccConfigureRemoteCluster(testAccXPackProvider, "terraform-test", "127.0.0.1:9300"); err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccElasticsearchXpackCcrAutofollowPattern,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackCcrAutofollowPatternExists("elasticsearch_xpack_ccr_autofollow_pattern.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_autofollow_pattern.test", "remote_cluster", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_autofollow_pattern.test", "leader_index_patterns.0", "terraform-test-leader-*"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_autofollow_pattern.test", "follow_index_pattern", "{{leader_index}}-follower"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_autofollow_pattern.test", "max_read_request_operation_count", "1024"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ccr_autofollow_pattern.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccConfigureRemoteCluster(provider *schema.Provider, alias, seed string) error {
+	body := map[string]interface{}{
+		"persistent": map[string]interface{}{
+			"cluster.remote." + alias + ".seeds": []string{seed},
+		},
+	}
+
+	esClient, err := getClient(provider.Meta().(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   "/_cluster/settings",
+			Body:   body,
+		})
+	case *elastic6.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   "/_cluster/settings",
+			Body:   body,
+		})
+	default:
+		err = fmt.Errorf("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+	return err
+}
+
+func testCheckElasticsearchXpackCcrAutofollowPatternExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No auto-follow pattern ID is set")
+		}
+
+		meta := testAccXPackProvider.Meta()
+
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			_, err = elastic7GetCcrAutofollowPattern(client, rs.Primary.ID)
+		case *elastic6.Client:
+			_, err = elastic6GetCcrAutofollowPattern(client, rs.Primary.ID)
+		default:
+			err = fmt.Errorf("Cross-cluster replication is only supported by the elastic library >= v6!")
+		}
+
+		return err
+	}
+}
+
+func testCheckElasticsearchXpackCcrAutofollowPatternDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ccr_autofollow_pattern" {
+			continue
+		}
+
+		meta := testAccXPackProvider.Meta()
+
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			_, err = elastic7GetCcrAutofollowPattern(client, rs.Primary.ID)
+		case *elastic6.Client:
+			_, err = elastic6GetCcrAutofollowPattern(client, rs.Primary.ID)
+		default:
+			err = fmt.Errorf("Cross-cluster replication is only supported by the elastic library >= v6!")
+		}
+
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Auto-follow pattern %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchXpackCcrAutofollowPatternLeader = `
+resource "elasticsearch_index" "leader" {
+  name = "terraform-test-leader-000001"
+  number_of_shards = 1
+  number_of_replicas = 0
+}
+`
+
+var testAccElasticsearchXpackCcrAutofollowPattern = testAccElasticsearchXpackCcrAutofollowPatternLeader + `
+resource "elasticsearch_xpack_ccr_autofollow_pattern" "test" {
+  name                             = "terraform-test"
+  remote_cluster                   = "terraform-test"
+  leader_index_patterns            = ["terraform-test-leader-*"]
+  follow_index_pattern             = "{{leader_index}}-follower"
+  max_read_request_operation_count = 1024
+}
+`
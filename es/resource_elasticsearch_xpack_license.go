@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 
@@ -29,10 +31,31 @@ func resourceElasticsearchXpackLicense() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"acknowledge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to acknowledge the feature changes of a license downgrade, otherwise ES rejects the license",
+			},
 			"license_json": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the active license, e.g. `basic` or `platinum`",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the active license, e.g. `active` or `expired`",
+			},
+			"expiry_date_in_millis": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The expiry of the active license, zero for licenses that don't expire",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -74,6 +97,9 @@ func resourceElasticsearchLicenseRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 	ds.set("license_json", string(out))
+	ds.set("type", l.Type)
+	ds.set("status", l.Status)
+	ds.set("expiry_date_in_millis", l.ExpiryDateInMillis)
 	return ds.err
 }
 
@@ -150,13 +176,14 @@ func resourceElasticsearchGetXpackLicense(meta interface{}) (License, error) {
 func resourceElasticsearchCreateXpackLicense(d *schema.ResourceData, meta interface{}) (string, error) {
 	license := d.Get("license").(string)
 	useBasicLicense := d.Get("use_basic_license").(bool)
+	acknowledge := d.Get("acknowledge").(bool)
 
 	var l License
 	var err error
 	if !useBasicLicense {
-		l, err = resourceElasticsearchPutEnterpriseLicense(license, meta)
+		l, err = resourceElasticsearchPutEnterpriseLicense(license, acknowledge, meta)
 	} else if d.Id() == "" {
-		l, err = resourceElasticsearchPostBasicLicense(meta)
+		l, err = resourceElasticsearchPostBasicLicense(acknowledge, meta)
 	} else {
 		log.Printf("[INFO] skipping creating basic license because already enabled %s", d.Id())
 	}
@@ -168,8 +195,10 @@ func resourceElasticsearchCreateXpackLicense(d *schema.ResourceData, meta interf
 	return l.Uid, nil
 }
 
-func resourceElasticsearchPutEnterpriseLicense(l string, meta interface{}) (License, error) {
+func resourceElasticsearchPutEnterpriseLicense(l string, acknowledge bool, meta interface{}) (License, error) {
 	request := fmt.Sprintf(`{"licenses": [%s]}`, l)
+	params := url.Values{}
+	params.Set("acknowledge", strconv.FormatBool(acknowledge))
 
 	var emptyLicense License
 	var body json.RawMessage
@@ -183,7 +212,8 @@ func resourceElasticsearchPutEnterpriseLicense(l string, meta interface{}) (Lice
 		var res *elastic7.Response
 		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 			Method: "PUT",
-			Path:   "/_license?acknowledge=true",
+			Path:   "/_license",
+			Params: params,
 			Body:   request,
 		})
 		body = res.Body
@@ -191,7 +221,8 @@ func resourceElasticsearchPutEnterpriseLicense(l string, meta interface{}) (Lice
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
 			Method: "PUT",
-			Path:   "/_xpack/license?acknowledge=true",
+			Path:   "/_xpack/license",
+			Params: params,
 			Body:   request,
 		})
 		body = res.Body
@@ -211,9 +242,12 @@ func resourceElasticsearchPutEnterpriseLicense(l string, meta interface{}) (Lice
 	return licenseResponse["licenses"][0], err
 }
 
-func resourceElasticsearchPostBasicLicense(meta interface{}) (License, error) {
+func resourceElasticsearchPostBasicLicense(acknowledge bool, meta interface{}) (License, error) {
 	var l License
 	var err error
+	params := url.Values{}
+	params.Set("acknowledge", strconv.FormatBool(acknowledge))
+
 	esClient, err := getClient(meta.(*ProviderConf))
 	if err != nil {
 		return l, err
@@ -222,12 +256,14 @@ func resourceElasticsearchPostBasicLicense(meta interface{}) (License, error) {
 	case *elastic7.Client:
 		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 			Method: "POST",
-			Path:   "/_license/start_basic?acknowledge=true",
+			Path:   "/_license/start_basic",
+			Params: params,
 		})
 	case *elastic6.Client:
 		_, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
 			Method: "POST",
-			Path:   "/_xpack/license/start_basic?acknowledge=true",
+			Path:   "/_xpack/license/start_basic",
+			Params: params,
 		})
 	default:
 		return l, errors.New("License is only supported by the elastic library >= v6!")
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	elastic7 "github.com/olivere/elastic/v7"
@@ -70,7 +71,7 @@ func resourceElasticsearchXpackRoleMappingCreate(d *schema.ResourceData, m inter
 	}
 	err = xpackPutRoleMapping(d, m, name, reqBody)
 	if err != nil {
-		return err
+		return xpackSecurityError(err)
 	}
 	d.SetId(name)
 	return resourceElasticsearchXpackRoleMappingRead(d, m)
@@ -96,7 +97,7 @@ func resourceElasticsearchXpackRoleMappingRead(d *schema.ResourceData, m interfa
 			d.SetId("")
 			return nil
 		}
-		return err
+		return xpackSecurityError(err)
 	}
 
 	ds := &resourceDataSetter{d: d}
@@ -117,7 +118,7 @@ func resourceElasticsearchXpackRoleMappingUpdate(d *schema.ResourceData, m inter
 	}
 	err = xpackPutRoleMapping(d, m, name, reqBody)
 	if err != nil {
-		return err
+		return xpackSecurityError(err)
 	}
 	return resourceElasticsearchXpackRoleMappingRead(d, m)
 }
@@ -147,6 +148,31 @@ func resourceElasticsearchXpackRoleMappingDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
+// xpackSecurityError explains the error returned by the security APIs of a
+// cluster which doesn't have the security features enabled, e.g. a basic
+// license without xpack.security.enabled.
+func xpackSecurityError(err error) error {
+	var reason string
+	switch e := err.(type) {
+	case *elastic7.Error:
+		if e.Details != nil {
+			reason = e.Details.Reason
+		}
+	case *elastic6.Error:
+		if e.Details != nil {
+			reason = e.Details.Reason
+		}
+	case *elastic5.Error:
+		if e.Details != nil {
+			reason = e.Details.Reason
+		}
+	}
+	if strings.Contains(reason, "xpack.security.enabled") {
+		return fmt.Errorf("security features are not enabled on this cluster, set xpack.security.enabled to true: %v", err)
+	}
+	return err
+}
+
 func buildPutRoleMappingBody(d *schema.ResourceData, m interface{}) (string, error) {
 	enabled := d.Get("enabled").(bool)
 	rules := d.Get("rules").(string)
@@ -129,6 +129,111 @@ func normalizedIndexSettings(settings map[string]interface{}) map[string]interfa
 	return f
 }
 
+// mappingRootParameters are the keys of a typeless mapping, a mapping with a
+// single other key is typed, as ES < 7 expects
+var mappingRootParameters = map[string]bool{
+	"properties":             true,
+	"dynamic":                true,
+	"dynamic_templates":      true,
+	"date_detection":         true,
+	"dynamic_date_formats":   true,
+	"numeric_detection":      true,
+	"enabled":                true,
+	"runtime":                true,
+	"_all":                   true,
+	"_data_stream_timestamp": true,
+	"_field_names":           true,
+	"_meta":                  true,
+	"_routing":               true,
+	"_size":                  true,
+	"_source":                true,
+}
+
+// mappingType returns the type of a typed mapping, e.g. `_doc` for
+// `{"_doc": {"properties": ...}}`
+func mappingType(mappings map[string]interface{}) (string, bool) {
+	if len(mappings) != 1 {
+		return "", false
+	}
+	for key, value := range mappings {
+		if _, ok := value.(map[string]interface{}); ok && !mappingRootParameters[key] {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// normalizeIndexMappings returns the typeless form of a mapping
+func normalizeIndexMappings(mappings map[string]interface{}) map[string]interface{} {
+	if typeName, ok := mappingType(mappings); ok {
+		return mappings[typeName].(map[string]interface{})
+	}
+	return mappings
+}
+
+// setIndexMappingsLayout converts the mappings of an index creation body to
+// the typed layout, with the `_doc` type unless one is given, or the typeless
+// one
+func setIndexMappingsLayout(body map[string]interface{}, typed bool) {
+	mappings, ok := body["mappings"].(map[string]interface{})
+	if !ok || len(mappings) == 0 {
+		return
+	}
+	if _, typedMappings := mappingType(mappings); typed && !typedMappings {
+		body["mappings"] = map[string]interface{}{"_doc": mappings}
+	} else if !typed {
+		body["mappings"] = normalizeIndexMappings(mappings)
+	}
+}
+
+// normalizeIndexAliases expands the `routing` shorthand of the aliases into
+// the index and search routings returned by the API
+func normalizeIndexAliases(aliases map[string]interface{}) {
+	for _, v := range aliases {
+		alias, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if routing, ok := alias["routing"]; ok {
+			delete(alias, "routing")
+			for _, key := range []string{"index_routing", "search_routing"} {
+				if _, ok := alias[key]; !ok {
+					alias[key] = routing
+				}
+			}
+		}
+		for _, key := range []string{"index_routing", "search_routing"} {
+			if routing, ok := alias[key]; ok {
+				alias[key] = fmt.Sprint(routing)
+			}
+		}
+	}
+}
+
+// indexAliasServerManagedFields are the fields of an alias which ES sets on
+// its own, e.g. when rolling it over
+var indexAliasServerManagedFields = []string{"is_write_index", "is_hidden"}
+
+// ignoreUnsetIndexAliasesFields removes the server managed fields of the read
+// aliases which aren't set in the configured ones
+func ignoreUnsetIndexAliasesFields(read, configured map[string]interface{}) {
+	for name, v := range configured {
+		alias, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		readAlias, ok := read[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range indexAliasServerManagedFields {
+			if _, ok := alias[key]; !ok {
+				delete(readAlias, key)
+			}
+		}
+	}
+}
+
 func normalizeIndexLifecyclePolicy(pol map[string]interface{}) {
 	delete(pol, "version")
 	delete(pol, "modified_date")
@@ -546,6 +651,15 @@ func tenantPermissionsHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
+func elastic6GetVersion(client *elastic6.Client) (*version.Version, error) {
+	urls := reflect.ValueOf(client).Elem().FieldByName("urls")
+	versionString, err := client.ElasticsearchVersion(urls.Index(0).String())
+	if err != nil {
+		return nil, err
+	}
+	return version.NewVersion(versionString)
+}
+
 func elastic7GetVersion(client *elastic7.Client) (*version.Version, error) {
 	urls := reflect.ValueOf(client).Elem().FieldByName("urls")
 	versionString, err := client.ElasticsearchVersion(urls.Index(0).String())
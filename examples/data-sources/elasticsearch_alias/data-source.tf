@@ -0,0 +1,7 @@
+data "elasticsearch_alias" "logs" {
+  name = "logs"
+}
+
+output "logs_indices" {
+  value = data.elasticsearch_alias.logs.indices
+}
@@ -0,0 +1,14 @@
+data "elasticsearch_cluster_health" "logs" {
+  index           = "logs-*"
+  wait_for_status = "green"
+  timeout         = "5m"
+}
+
+# wait for the shards to settle after changing allocation settings
+data "elasticsearch_cluster_health" "settled" {
+  wait_for_no_relocating_shards   = true
+  wait_for_no_initializing_shards = true
+  timeout                         = "10m"
+
+  depends_on = [elasticsearch_index.logs]
+}
@@ -0,0 +1,12 @@
+data "elasticsearch_cluster_settings" "current" {
+  include_defaults = true
+  flat_settings    = true
+}
+
+locals {
+  allocation_enable = lookup(
+    jsondecode(data.elasticsearch_cluster_settings.current.persistent),
+    "cluster.routing.allocation.enable",
+    jsondecode(data.elasticsearch_cluster_settings.current.defaults)["cluster.routing.allocation.enable"],
+  )
+}
@@ -0,0 +1,5 @@
+data "elasticsearch_dangling_indices" "all" {}
+
+output "dangling_index_names" {
+  value = data.elasticsearch_dangling_indices.all.dangling_indices.*.index_name
+}
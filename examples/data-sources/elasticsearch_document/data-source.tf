@@ -0,0 +1,8 @@
+data "elasticsearch_document" "config" {
+  index = "app-config"
+  id    = "production"
+}
+
+output "feature_flags" {
+  value = jsondecode(data.elasticsearch_document.config.source).feature_flags
+}
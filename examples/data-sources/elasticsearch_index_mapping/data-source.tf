@@ -0,0 +1,7 @@
+data "elasticsearch_index_mapping" "logs" {
+  index = "logs-write"
+}
+
+output "log_fields" {
+  value = keys(jsondecode(data.elasticsearch_index_mapping.logs.mappings).properties)
+}
@@ -0,0 +1,7 @@
+data "elasticsearch_indices" "logs" {
+  pattern = "logs-*"
+}
+
+output "log_indices" {
+  value = data.elasticsearch_indices.logs.names
+}
@@ -0,0 +1,9 @@
+data "elasticsearch_nodes" "data" {
+  role = "data"
+}
+
+resource "elasticsearch_index" "logs" {
+  name               = "logs"
+  number_of_shards   = 1
+  number_of_replicas = min(2, length(data.elasticsearch_nodes.data.names) - 1)
+}
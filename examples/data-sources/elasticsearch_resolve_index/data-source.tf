@@ -0,0 +1,7 @@
+data "elasticsearch_resolve_index" "logs" {
+  name = "logs-2021.01.01"
+}
+
+output "logs_template" {
+  value = data.elasticsearch_resolve_index.logs.template
+}
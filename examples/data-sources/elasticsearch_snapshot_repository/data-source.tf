@@ -0,0 +1,3 @@
+data "elasticsearch_snapshot_repository" "backups" {
+  name = "backups"
+}
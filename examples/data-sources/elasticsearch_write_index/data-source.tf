@@ -0,0 +1,7 @@
+data "elasticsearch_write_index" "logs" {
+  alias = "logs"
+}
+
+output "logs_write_index" {
+  value = data.elasticsearch_write_index.logs.index
+}
@@ -0,0 +1,11 @@
+resource "elasticsearch_index" "products_v2" {
+  name               = "products-v2"
+  number_of_shards   = 1
+  number_of_replicas = 1
+}
+
+resource "elasticsearch_alias_swap" "products" {
+  alias     = "products"
+  old_index = "products-v1"
+  new_index = elasticsearch_index.products_v2.name
+}
@@ -0,0 +1,13 @@
+resource "elasticsearch_cluster_reroute" "move_logs" {
+  commands = jsonencode([
+    {
+      "move" = {
+        "index"     = "logs"
+        "shard"     = 0
+        "from_node" = "node-1"
+        "to_node"   = "node-2"
+      }
+    }
+  ])
+  retry_failed = true
+}
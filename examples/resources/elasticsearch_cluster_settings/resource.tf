@@ -0,0 +1,6 @@
+resource "elasticsearch_cluster_settings" "allocation" {
+  cluster_routing_allocation_enable                     = "all"
+  cluster_routing_allocation_disk_watermark_low         = "85%"
+  cluster_routing_allocation_disk_watermark_high        = "90%"
+  cluster_routing_allocation_disk_watermark_flood_stage = "95%"
+}
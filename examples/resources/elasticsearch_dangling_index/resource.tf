@@ -0,0 +1,12 @@
+data "elasticsearch_dangling_indices" "all" {}
+
+resource "elasticsearch_dangling_index" "restored" {
+  for_each = {
+    for index in data.elasticsearch_dangling_indices.all.dangling_indices : index.index_uuid => index
+    if index.index_name == "orders"
+  }
+
+  index_uuid       = each.key
+  action           = "import"
+  accept_data_loss = true
+}
@@ -0,0 +1,4 @@
+resource "elasticsearch_forcemerge" "logs" {
+  index            = "logs-*"
+  max_num_segments = 1
+}
@@ -0,0 +1,4 @@
+resource "elasticsearch_index_state" "archive" {
+  index = "logs-2020.12"
+  state = "closed"
+}
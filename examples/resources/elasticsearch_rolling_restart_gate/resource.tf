@@ -0,0 +1,4 @@
+# Create before restarting the nodes one by one, destroy once they are all back
+resource "elasticsearch_rolling_restart_gate" "maintenance" {
+  cluster_routing_allocation_enable = "primaries"
+}
@@ -0,0 +1,16 @@
+# Create a search template, validated against sample params during plan
+resource "elasticsearch_search_template" "test" {
+  template_id = "my_template"
+  source = jsonencode({
+    query = {
+      match = {
+        message = "{{query_string}}"
+      }
+    }
+  })
+
+  validate = true
+  params = jsonencode({
+    query_string = "hello world"
+  })
+}
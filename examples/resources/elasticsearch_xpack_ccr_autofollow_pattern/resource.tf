@@ -0,0 +1,9 @@
+resource "elasticsearch_xpack_ccr_autofollow_pattern" "logs" {
+  name                  = "logs"
+  remote_cluster        = "leader"
+  leader_index_patterns = ["logs-*"]
+  follow_index_pattern  = "{{leader_index}}-copy"
+
+  max_read_request_operation_count = 5120
+  read_poll_timeout                = "1m"
+}
@@ -6,7 +6,11 @@ import (
 	"github.com/phillbaker/terraform-provider-elasticsearch/es"
 )
 
+// version is set by the release build
+var version = "dev"
+
 func main() {
+	es.ProviderVersion = version
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: es.Provider,
 	})